@@ -0,0 +1,102 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/pkg/errors"
+	"fmt"
+)
+
+const maxBatchOrders = 50
+
+// ExecuteBatch applies a list of market orders all-or-nothing in a single
+// transaction. Sells run before buys so their proceeds can fund the purchases;
+// if any order fails none of them is applied.
+func (s *service) ExecuteBatch(sessionID string, orders []game_session.MarketOrder) ([]game_session.Trade, error) {
+	if len(orders) == 0 {
+		return nil, errors.New(errors.ErrInvalidInput, "batch must contain at least one order")
+	}
+	if len(orders) > maxBatchOrders {
+		return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("batch cannot contain more than %d orders", maxBatchOrders))
+	}
+	for i, order := range orders {
+		if !order.Side.IsValid() {
+			return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("order %d: invalid order side: %s", i, order.Side))
+		}
+		if order.Quantity <= 0 {
+			return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("order %d: quantity must be positive", i))
+		}
+	}
+
+	tx, err := s.repo.BeginTransaction(sessionID)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	session := tx.GetSession()
+
+	currentWeek, err := getCurrentWeek(session.Status)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "failed to get current week", err)
+	}
+
+	gmData, err := s.gmService.GetWeekData(sessionID, currentWeek)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to get GM week data", err)
+	}
+	gmData = gmData.OnDay(session.Day)
+
+	for i, order := range orders {
+		if _, found := findStockPrice(gmData, order.Ticker); !found {
+			return nil, errors.New(errors.ErrNotFound, fmt.Sprintf("order %d: stock %s not found in current week data", i, order.Ticker))
+		}
+	}
+
+	var trades []*game_session.Trade
+	for _, side := range []game_session.OrderSide{game_session.OrderSideSell, game_session.OrderSideBuy} {
+		for i, order := range orders {
+			if order.Side != side {
+				continue
+			}
+
+			price, _ := findStockPrice(gmData, order.Ticker)
+
+			var trade *game_session.Trade
+			if side == game_session.OrderSideBuy {
+				trade, err = buyShares(session, order.Ticker, order.Quantity, price, s.fees)
+			} else {
+				trade, err = sellShares(session, order.Ticker, order.Quantity, price, s.fees)
+			}
+			if err != nil {
+				return nil, errors.Wrap(errors.ErrInvalidInput, fmt.Sprintf("order %d: failed to %s %d %s", i, side, order.Quantity, order.Ticker), err)
+			}
+
+			trade.Week = currentWeek
+			trade.Day = session.Day
+			trade.Source = game_session.TradeSourceManual
+			trades = append(trades, trade)
+		}
+	}
+
+	// Trades are recorded before the session is updated because Update also
+	// writes the holdings to Redis, which the rollback cannot undo.
+	executed := make([]game_session.Trade, 0, len(trades))
+	for _, trade := range trades {
+		if err := tx.RecordTrade(trade); err != nil {
+			return nil, errors.Wrap(errors.ErrInternal, "failed to record trade", err)
+		}
+		executed = append(executed, *trade)
+	}
+
+	updateValuation(session, gmData)
+
+	if err := tx.Update(session); err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to update session", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to commit transaction", err)
+	}
+
+	return executed, nil
+}
@@ -0,0 +1,45 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+)
+
+// benchmarkReturn is the return of an equal-weight buy-and-hold of every stock
+// in play, bought at the first day's prices and sold at the last day's,
+// reinvesting dividends and following splits along the way.
+func benchmarkReturn(steps []tradingStep) float64 {
+	first, final := steps[0].gmData, steps[len(steps)-1].gmData
+
+	var total float64
+	var count int
+	for _, stock := range first.Stocks {
+		if _, found := findStockPrice(final, stock.Ticker); !found || stock.Price <= 0 {
+			continue
+		}
+
+		growth := 1.0
+		price := stock.Price
+		for _, step := range steps[1:] {
+			nextPrice, _ := findStockPrice(step.gmData, stock.Ticker)
+			if price > 0 {
+				growth *= shareValue(step.gmData, stock.Ticker).Ratio(price)
+			}
+			price = nextPrice
+		}
+		total += growth - 1
+		count++
+	}
+
+	if count == 0 {
+		return 0
+	}
+	return total / float64(count)
+}
+
+// scoreAgainstBenchmark sets the player's return on the starting cash and how
+// far it beat the benchmark.
+func scoreAgainstBenchmark(session *game_session.GameSession, benchmark float64) {
+	session.Return = (session.TotalBalance - session.Rules.StartingCash).Ratio(session.Rules.StartingCash)
+	session.BenchmarkReturn = benchmark
+	session.Alpha = session.Return - benchmark
+}
@@ -0,0 +1,101 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/domain/gm_session"
+	"backend/pkg/money"
+	"math"
+	"testing"
+)
+
+func TestBenchmarkReturn(t *testing.T) {
+	step := func(gmData *gm_session.GMWeekData) tradingStep {
+		return tradingStep{week: 1, day: 1, gmData: gmData}
+	}
+
+	tests := []struct {
+		name  string
+		steps []tradingStep
+		want  float64
+	}{
+		{
+			name:  "one snapshot",
+			steps: []tradingStep{step(stocksAt(stockAt("AAPL", 10000)))},
+		},
+		{
+			name:  "all cash",
+			steps: []tradingStep{step(stocksAt()), step(stocksAt())},
+		},
+		{
+			name: "flat prices",
+			steps: []tradingStep{
+				step(stocksAt(stockAt("AAPL", 10000))),
+				step(stocksAt(stockAt("AAPL", 10000))),
+			},
+		},
+		{
+			name: "compounds daily growth",
+			steps: []tradingStep{
+				step(stocksAt(stockAt("AAPL", 10000), stockAt("MSFT", 10000))),
+				step(stocksAt(stockAt("AAPL", 11000), stockAt("MSFT", 9000))),
+				step(stocksAt(stockAt("AAPL", 12100), stockAt("MSFT", 9900))),
+			},
+			want: (0.21 - 0.01) / 2,
+		},
+		{
+			name: "reinvests dividends",
+			steps: []tradingStep{
+				step(stocksAt(stockAt("AAPL", 10000))),
+				step(&gm_session.GMWeekData{
+					Stocks: []gm_session.StockWeekInsight{stockAt("AAPL", 10000)},
+					Events: []gm_session.CorporateAction{{Ticker: "AAPL", Type: game_session.ActionDividend, Amount: 1000}},
+				}),
+				step(stocksAt(stockAt("AAPL", 11000))),
+			},
+			want: 0.21,
+		},
+		{
+			name: "follows splits",
+			steps: []tradingStep{
+				step(stocksAt(stockAt("AAPL", 10000))),
+				step(&gm_session.GMWeekData{
+					Stocks: []gm_session.StockWeekInsight{stockAt("AAPL", 5500)},
+					Events: []gm_session.CorporateAction{{Ticker: "AAPL", Type: game_session.ActionSplit, Ratio: 2}},
+				}),
+				step(stocksAt(stockAt("AAPL", 6050))),
+			},
+			want: 0.21,
+		},
+		{
+			name: "skips stocks missing at the end",
+			steps: []tradingStep{
+				step(stocksAt(stockAt("AAPL", 10000), stockAt("MSFT", 10000))),
+				step(stocksAt(stockAt("AAPL", 12000))),
+			},
+			want: 0.2,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := benchmarkReturn(tt.steps); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: benchmark return %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestScoreAgainstBenchmark(t *testing.T) {
+	session := newTestSession(money.FromCents(1100000))
+	session.Rules.StartingCash = money.FromCents(1000000)
+
+	scoreAgainstBenchmark(session, 0.25)
+
+	if math.Abs(session.Return-0.1) > 1e-9 {
+		t.Errorf("return %v, want 0.1", session.Return)
+	}
+	if session.BenchmarkReturn != 0.25 {
+		t.Errorf("benchmark return %v, want 0.25", session.BenchmarkReturn)
+	}
+	if math.Abs(session.Alpha+0.15) > 1e-9 {
+		t.Errorf("alpha %v, want -0.15", session.Alpha)
+	}
+}
@@ -0,0 +1,164 @@
+package game_session
+
+import (
+	"backend/domain/challenge"
+	"backend/domain/game_session"
+	"backend/pkg/errors"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// StartChallenge starts a session on the day's challenge. The challenge is
+// crafted by the first session of the day and replayed by every other one.
+// Each username gets a single attempt a day, as a replay would be played
+// knowing the prices.
+func (s *service) StartChallenge(username string) (string, error) {
+	date := time.Now().UTC().Format(challenge.DateLayout)
+
+	session, err := newSession(username, nil, game_session.DefaultRules())
+	if err != nil {
+		return "", err
+	}
+	session.Mode = game_session.ModeChallenge
+	session.ChallengeDate = date
+	sessionID := session.SessionID
+
+	if err := s.repo.Save(session); err != nil {
+		return "", err
+	}
+
+	s.taskRunner.Dispatch(func() {
+		err := s.craftChallengeSession(sessionID, date)
+		if err != nil {
+			// Log the error but don't return it since this is a background task
+			fmt.Printf("Error crafting challenge for session %s: %v\n", sessionID, err)
+		}
+		s.publishCrafted(sessionID, err)
+	})
+
+	return sessionID, nil
+}
+
+func (s *service) GetChallenge(date string) (*challenge.DailyChallenge, error) {
+	if _, err := time.Parse(challenge.DateLayout, date); err != nil {
+		return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("invalid challenge date: %s", date))
+	}
+	return s.challengeRepo.FindByDate(date)
+}
+
+// craftChallengeSession loads the day's challenge into the session.
+func (s *service) craftChallengeSession(sessionID string, date string) error {
+	s.publishProgress(sessionID, game_session.StepScenario)
+	daily, err := s.dailyChallenge(date)
+	if err != nil {
+		return s.failChallengeSession(sessionID, fmt.Errorf("failed to get daily challenge: %w", err))
+	}
+
+	if err := s.repo.SetCategories(sessionID, daily.Categories); err != nil {
+		return s.failChallengeSession(sessionID, fmt.Errorf("failed to save categories: %w", err))
+	}
+
+	s.publishProgress(sessionID, game_session.StepSaving)
+	if err := s.gmService.SaveGMWeekData(sessionID, daily.WeekData, daily.Rules); err != nil {
+		return s.failChallengeSession(sessionID, fmt.Errorf("failed to save GM week data: %w", err))
+	}
+
+	if err := s.repo.UpdateGameCraftingStatus(sessionID, true); err != nil {
+		return fmt.Errorf("failed to update session status to week1: %w", err)
+	}
+
+	return nil
+}
+
+// failChallengeSession marks the session as failed and gives its player back
+// the day's attempt, since a session that never started revealed nothing.
+// It returns the error that made crafting fail.
+func (s *service) failChallengeSession(sessionID string, cause error) error {
+	if err := s.repo.UpdateGameCraftingStatus(sessionID, false); err != nil {
+		return fmt.Errorf("failed to update session status after challenge error: %w", err)
+	}
+	if err := s.repo.ReleaseChallengeAttempt(sessionID); err != nil {
+		return fmt.Errorf("failed to release challenge attempt after challenge error: %w", err)
+	}
+	return cause
+}
+
+// dailyChallenge returns the challenge of the given day, crafting it when it
+// does not exist yet. Sessions starting together may each craft it, but only
+// the first one stored is played.
+func (s *service) dailyChallenge(date string) (*challenge.DailyChallenge, error) {
+	daily, err := s.challengeRepo.FindByDate(date)
+	if err == nil {
+		return daily, nil
+	}
+	if errors.GetCode(err) != errors.ErrNotFound {
+		return nil, err
+	}
+
+	crafted, err := s.craftDailyChallenge(date)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.challengeRepo.Create(crafted); err != nil {
+		return nil, err
+	}
+
+	// Read it back, as another instance may have stored the day's challenge first.
+	return s.challengeRepo.FindByDate(date)
+}
+
+// craftDailyChallenge builds a day's scenario. The categories and stocks are
+// picked with the date as seed, so only the GM's story differs if a day is
+// ever crafted twice.
+func (s *service) craftDailyChallenge(date string) (*challenge.DailyChallenge, error) {
+	rules := game_session.DefaultRules()
+
+	allCategories, err := s.categoryRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	if len(allCategories) < game_session.CategoriesPerSession {
+		return nil, fmt.Errorf("need %d categories, found %d", game_session.CategoriesPerSession, len(allCategories))
+	}
+
+	names := make([]string, len(allCategories))
+	for i, cat := range allCategories {
+		names[i] = cat.Name
+	}
+	sort.Strings(names)
+
+	hash := fnv.New64a()
+	fmt.Fprint(hash, date)
+	random := rand.New(rand.NewSource(int64(hash.Sum64())))
+	random.Shuffle(len(names), func(i, j int) {
+		names[i], names[j] = names[j], names[i]
+	})
+	categories := names[:game_session.CategoriesPerSession]
+
+	stocks, err := s.stockRepo.PickStocksForSession(categories, rules.StocksPerCategory, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pick stocks: %w", err)
+	}
+
+	gmData, err := s.aiModel.GetGMResponse(context.Background(), categories, stocks, rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GM response: %w", err)
+	}
+
+	tagCategories(gmData, stocks)
+
+	if err := s.gmService.PrepareWeekData(date, gmData, rules); err != nil {
+		return nil, fmt.Errorf("failed to prepare GM week data: %w", err)
+	}
+
+	return &challenge.DailyChallenge{
+		Date:       date,
+		Categories: categories,
+		Rules:      rules,
+		WeekData:   gmData,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package game_session
+
+import (
+	"backend/domain/challenge"
+	"backend/domain/game_session"
+	"backend/infrastructure/taskrunner"
+	"backend/pkg/errors"
+	"fmt"
+	"testing"
+)
+
+// challengeSessions stores sessions in memory and enforces
+// idx_challenge_attempt: one dated challenge session per username and day.
+type challengeSessions struct {
+	game_session.Repository
+	sessions map[string]*game_session.GameSession
+}
+
+func (r *challengeSessions) Save(session *game_session.GameSession) error {
+	for _, other := range r.sessions {
+		if other.Mode == game_session.ModeChallenge && other.ChallengeDate != "" &&
+			other.ChallengeDate == session.ChallengeDate && other.Username == session.Username {
+			return errors.New(errors.ErrConflict, fmt.Sprintf("%s has already played the %s challenge", session.Username, session.ChallengeDate))
+		}
+	}
+	r.sessions[session.SessionID] = session
+	return nil
+}
+
+func (r *challengeSessions) UpdateGameCraftingStatus(sessionID string, success bool) error {
+	if success {
+		r.sessions[sessionID].Status = game_session.StatusWeek1
+	} else {
+		r.sessions[sessionID].Status = game_session.StatusExpired
+	}
+	return nil
+}
+
+func (r *challengeSessions) ReleaseChallengeAttempt(sessionID string) error {
+	r.sessions[sessionID].ChallengeDate = ""
+	return nil
+}
+
+// unavailableChallenges fails every lookup, as when the database is down.
+type unavailableChallenges struct {
+	challenge.Repository
+}
+
+func (unavailableChallenges) FindByDate(date string) (*challenge.DailyChallenge, error) {
+	return nil, errors.New(errors.ErrInternal, "failed to find challenge")
+}
+
+type discardEvents struct {
+	game_session.EventBus
+}
+
+func (discardEvents) Publish(event game_session.Event) error {
+	return nil
+}
+
+// TestChallengeRetryAfterCraftingFailure checks a challenge that failed to
+// craft does not use up the player's attempt for the day.
+func TestChallengeRetryAfterCraftingFailure(t *testing.T) {
+	repo := &challengeSessions{sessions: make(map[string]*game_session.GameSession)}
+	s := &service{
+		repo:          repo,
+		challengeRepo: unavailableChallenges{},
+		events:        discardEvents{},
+		// Not started: the test crafts the sessions itself.
+		taskRunner: taskrunner.New(2),
+	}
+
+	sessionID, err := s.StartChallenge("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.StartChallenge("alice"); errors.GetCode(err) != errors.ErrConflict {
+		t.Fatalf("second start while crafting returned %v, want a conflict", err)
+	}
+
+	if err := s.craftChallengeSession(sessionID, repo.sessions[sessionID].ChallengeDate); err == nil {
+		t.Fatal("crafting succeeded, want an error")
+	}
+	if status := repo.sessions[sessionID].Status; status != game_session.StatusExpired {
+		t.Errorf("failed session status %s, want %s", status, game_session.StatusExpired)
+	}
+
+	retryID, err := s.StartChallenge("alice")
+	if err != nil {
+		t.Fatalf("retry after crafting failure: %v", err)
+	}
+	if retryID == sessionID {
+		t.Errorf("retry reused session %s", sessionID)
+	}
+}
@@ -0,0 +1,159 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/domain/gm_session"
+	"backend/pkg/money"
+)
+
+// applyCorporateActions applies the week's dividends and splits to the
+// holdings. Splits rescale the share count while keeping the cost basis, so
+// the position is worth the same at the split-adjusted price, and rescale the
+// protection thresholds and open limit orders on the stock to match.
+func applyCorporateActions(session *game_session.GameSession, week int, gmData *gm_session.GMWeekData) {
+	for _, action := range gmData.Events {
+		if action.Type != game_session.ActionDividend {
+			rescaleOrders(session, action)
+		}
+
+		holding, ok := session.Metadata.Holdings[action.Ticker]
+		if !ok || holding.Quantity == 0 {
+			continue
+		}
+
+		before := holding.Quantity
+		cash := money.Zero
+		switch action.Type {
+		case game_session.ActionDividend:
+			// Short positions pay the dividend to the lender.
+			cash = action.Amount.Mul(holding.Quantity)
+			holding.RealizedPnL += cash
+		case game_session.ActionSplit:
+			holding.Quantity *= action.Ratio
+			holding.StopLoss = holding.StopLoss.MulDiv(1, int64(action.Ratio))
+			holding.TakeProfit = holding.TakeProfit.MulDiv(1, int64(action.Ratio))
+			holding.ProtectedQuantity *= action.Ratio
+		case game_session.ActionReverseSplit:
+			cash = reverseSplit(&holding, action.Ticker, action.Ratio, gmData)
+		}
+
+		session.Cash += cash
+		session.Metadata.Holdings[action.Ticker] = holding
+		session.Metadata.CorporateActions = append(session.Metadata.CorporateActions, game_session.CorporateActionFill{
+			Ticker:         action.Ticker,
+			Week:           week,
+			Type:           action.Type,
+			QuantityBefore: before,
+			QuantityAfter:  holding.Quantity,
+			Cash:           cash,
+		})
+	}
+}
+
+// reverseSplit merges the holding's shares and settles the fraction of a
+// share left over at the new price. It returns the change in cash: the
+// payment in lieu of the fraction, plus the collateral released when buying
+// back the fraction of a short position.
+func reverseSplit(holding *game_session.HoldingInfo, ticker string, ratio int, gmData *gm_session.GMWeekData) money.Money {
+	quantity := holding.Quantity
+	fraction := quantity % ratio
+	cash := money.Zero
+
+	if fraction != 0 {
+		price, _ := findStockPrice(gmData, ticker)
+		inLieu := price.MulDiv(int64(fraction), int64(ratio))
+		basis := holding.TotalSpent.MulDiv(int64(fraction), int64(quantity))
+		holding.TotalSpent -= basis
+		cash = inLieu
+
+		if holding.IsShort() {
+			released := holding.Collateral.MulDiv(int64(fraction), int64(quantity))
+			holding.Collateral -= released
+			holding.RealizedPnL += basis + inLieu
+			cash += released
+		} else {
+			holding.RealizedPnL += inLieu - basis
+		}
+	}
+
+	holding.Quantity = quantity / ratio
+	if holding.Quantity == 0 {
+		*holding = game_session.HoldingInfo{RealizedPnL: holding.RealizedPnL}
+		return cash
+	}
+
+	holding.StopLoss = holding.StopLoss.Mul(ratio)
+	holding.TakeProfit = holding.TakeProfit.Mul(ratio)
+	if holding.ProtectedQuantity > 0 {
+		holding.ProtectedQuantity = max(holding.ProtectedQuantity/ratio, 1)
+	}
+	return cash
+}
+
+// rescaleOrders adjusts the open limit orders on a stock to a split, cancelling
+// those too small to survive a reverse split.
+func rescaleOrders(session *game_session.GameSession, action gm_session.CorporateAction) {
+	for i := range session.Metadata.Orders {
+		order := &session.Metadata.Orders[i]
+		if order.Status != game_session.OrderStatusOpen || order.Ticker != action.Ticker {
+			continue
+		}
+
+		if action.Type == game_session.ActionSplit {
+			order.Quantity *= action.Ratio
+			order.LimitPrice = order.LimitPrice.MulDiv(1, int64(action.Ratio))
+			continue
+		}
+
+		order.Quantity /= action.Ratio
+		order.LimitPrice = order.LimitPrice.Mul(action.Ratio)
+		if order.Quantity == 0 {
+			order.Status = game_session.OrderStatusCancelled
+		}
+	}
+}
+
+// shareValue is what one share held going into the given week is worth at
+// its prices once its corporate actions have applied: the dividends received
+// plus the shares it became.
+func shareValue(gmData *gm_session.GMWeekData, ticker string) money.Money {
+	price, _ := findStockPrice(gmData, ticker)
+	shares, per := int64(1), int64(1)
+	dividends := money.Zero
+	for _, action := range gmData.Events {
+		if action.Ticker != ticker {
+			continue
+		}
+		switch action.Type {
+		case game_session.ActionDividend:
+			dividends += action.Amount.MulDiv(shares, per)
+		case game_session.ActionSplit:
+			shares *= int64(action.Ratio)
+		case game_session.ActionReverseSplit:
+			per *= int64(action.Ratio)
+		}
+	}
+	return dividends + price.MulDiv(shares, per)
+}
+
+// splitPositions rescales whole-share positions to the given week's splits,
+// dropping the fractions a reverse split leaves over.
+func splitPositions(positions map[string]int, gmData *gm_session.GMWeekData) map[string]int {
+	split := make(map[string]int, len(positions))
+	for ticker, quantity := range positions {
+		split[ticker] = quantity
+	}
+	for _, action := range gmData.Events {
+		quantity, ok := split[action.Ticker]
+		if !ok {
+			continue
+		}
+		switch action.Type {
+		case game_session.ActionSplit:
+			split[action.Ticker] = quantity * action.Ratio
+		case game_session.ActionReverseSplit:
+			split[action.Ticker] = quantity / action.Ratio
+		}
+	}
+	return split
+}
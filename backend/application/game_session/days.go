@@ -0,0 +1,70 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/pkg/errors"
+	"fmt"
+)
+
+// AdvanceDay moves the session to the next trading day of the week. Stop-loss
+// and take-profit thresholds, short buy-ins and limit orders are checked
+// against the new day's prices, as they are when a new week starts.
+func (s *service) AdvanceDay(sessionID string) error {
+	tx, err := s.repo.BeginTransaction(sessionID)
+	if err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	session := tx.GetSession()
+	if session.Mode == game_session.ModeRoom {
+		return errors.New(errors.ErrConflict, "the session advances along with its room")
+	}
+
+	currentWeek, err := getCurrentWeek(session.Status)
+	if err != nil {
+		return errors.Wrap(errors.ErrInvalidInput, "failed to get current week", err)
+	}
+
+	if session.Day >= game_session.TradingDaysPerWeek {
+		return errors.New(errors.ErrInvalidInput, fmt.Sprintf("day %d is the last trading day of the week: advance to the next week", game_session.TradingDaysPerWeek))
+	}
+
+	weekData, err := s.gmService.GetWeekData(sessionID, currentWeek)
+	if err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to get GM week data", err)
+	}
+
+	nextDay := session.Day + 1
+	gmData := weekData.OnDay(nextDay)
+
+	ensureMetadata(session)
+	trades := executeProtections(session, currentWeek, gmData, s.fees)
+	trades = append(trades, executeBuyIns(session, currentWeek, gmData, s.fees)...)
+	trades = append(trades, executeLimitOrders(session, currentWeek, nextDay, gmData, s.fees)...)
+	for _, trade := range trades {
+		trade.Day = nextDay
+	}
+
+	session.Day = nextDay
+	updateValuation(session, gmData)
+
+	// The fills are recorded before the session is updated because Update also
+	// writes the holdings to Redis, which the rollback cannot undo.
+	for _, trade := range trades {
+		if err := tx.RecordTrade(trade); err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to record trade", err)
+		}
+	}
+
+	if err := tx.Update(session); err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to update session", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to commit transaction", err)
+	}
+
+	s.publishFills(sessionID, currentWeek, nextDay, trades)
+	return nil
+}
@@ -0,0 +1,172 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/pkg/errors"
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// expiryWarning is how long before a session expires its player is warned.
+	expiryWarning       = 15 * time.Minute
+	expiryCheckInterval = time.Minute
+	// StreamTicketTTL is how long a stream ticket can be redeemed, enough to
+	// open the stream right after asking for it.
+	StreamTicketTTL = 30 * time.Second
+)
+
+// IssueStreamTicket returns a single-use ticket that opens the session's
+// event stream. Browsers cannot send headers when opening a stream, and the
+// ticket keeps the session ID, which grants full control of the session, out
+// of URLs and access logs.
+func (s *service) IssueStreamTicket(sessionID string) (string, error) {
+	if _, err := s.repo.FindBySessionID(sessionID); err != nil {
+		return "", err
+	}
+
+	ticket, err := generateSecureToken()
+	if err != nil {
+		return "", errors.Wrap(errors.ErrInternal, "failed to generate stream ticket", err)
+	}
+	if err := s.repo.SaveStreamTicket(ticket, sessionID, StreamTicketTTL); err != nil {
+		return "", err
+	}
+	return ticket, nil
+}
+
+// RedeemStreamTicket returns the session ID the ticket was issued for. A
+// ticket can only be redeemed once.
+func (s *service) RedeemStreamTicket(ticket string) (string, error) {
+	if ticket == "" {
+		return "", errors.New(errors.ErrUnauthorized, "missing stream ticket")
+	}
+	return s.repo.TakeStreamTicket(ticket)
+}
+
+// StreamEvents delivers the session's events until ctx is done. Besides the
+// events published by any API instance, it warns once the session is about to
+// expire for lack of play. Events published before the stream opened are not
+// replayed, so clients should read the session state once subscribed.
+func (s *service) StreamEvents(ctx context.Context, sessionID string) (<-chan game_session.Event, error) {
+	if _, err := s.repo.FindBySessionID(sessionID); err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.events.Subscribe(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan game_session.Event)
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(expiryCheckInterval)
+		defer ticker.Stop()
+
+		warned, finished := false, false
+		for {
+			var event game_session.Event
+			select {
+			case <-ctx.Done():
+				return
+			case published, ok := <-subscription:
+				if !ok {
+					return
+				}
+				event = published
+				finished = finished || event.Type == game_session.EventSessionFinished
+			case <-ticker.C:
+				if finished {
+					continue
+				}
+				expiresAt, err := s.repo.ExpiresAt(sessionID)
+				if err != nil || expiresAt.IsZero() {
+					continue
+				}
+				// Playing the session pushes its expiry back, warranting a new
+				// warning when it comes close again.
+				if time.Until(expiresAt) > expiryWarning {
+					warned = false
+					continue
+				}
+				if warned {
+					continue
+				}
+				warned = true
+				event = game_session.Event{
+					Type:      game_session.EventSessionExpiring,
+					SessionID: sessionID,
+					ExpiresAt: expiresAt.Format(time.RFC3339),
+					CreatedAt: time.Now().Format(time.RFC3339),
+				}
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// publish sends the event to the session's subscribers. Events are a
+// convenience for the client, so failing to publish one is only logged.
+func (s *service) publish(event game_session.Event) {
+	event.CreatedAt = time.Now().Format(time.RFC3339)
+	if err := s.events.Publish(event); err != nil {
+		fmt.Printf("Error publishing %s event for session %s: %v\n", event.Type, event.SessionID, err)
+	}
+}
+
+func (s *service) publishProgress(sessionID string, step game_session.CraftingStep) {
+	s.publish(game_session.Event{
+		Type:      game_session.EventCraftingProgress,
+		SessionID: sessionID,
+		Step:      step,
+	})
+}
+
+// publishCrafted announces whether the session's scenario was crafted, given
+// the error crafting it returned.
+func (s *service) publishCrafted(sessionID string, err error) {
+	if err != nil {
+		s.publish(game_session.Event{
+			Type:      game_session.EventCraftingFailed,
+			SessionID: sessionID,
+			Message:   "the game could not be crafted, please start a new session",
+		})
+		return
+	}
+
+	s.publish(game_session.Event{
+		Type:      game_session.EventCraftingSucceeded,
+		SessionID: sessionID,
+		Week:      1,
+		Day:       1,
+	})
+}
+
+// publishFills announces the trades executed automatically, if any.
+func (s *service) publishFills(sessionID string, week int, day int, trades []*game_session.Trade) {
+	if len(trades) == 0 {
+		return
+	}
+
+	fills := make([]game_session.Trade, len(trades))
+	for i, trade := range trades {
+		fills[i] = *trade
+	}
+	s.publish(game_session.Event{
+		Type:      game_session.EventOrderFilled,
+		SessionID: sessionID,
+		Week:      week,
+		Day:       day,
+		Trades:    fills,
+	})
+}
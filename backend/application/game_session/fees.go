@@ -0,0 +1,105 @@
+package game_session
+
+import (
+	"backend/pkg/errors"
+	"backend/pkg/money"
+	"fmt"
+)
+
+// FeeModel computes the commission charged on a trade from its notional
+// value (price times quantity).
+type FeeModel interface {
+	Fee(notional money.Money) money.Money
+}
+
+// NoFee keeps trading free of charge.
+type NoFee struct{}
+
+func (NoFee) Fee(money.Money) money.Money {
+	return money.Zero
+}
+
+// FlatFee charges the same amount on every trade.
+type FlatFee struct {
+	Amount money.Money
+}
+
+func (f FlatFee) Fee(money.Money) money.Money {
+	return f.Amount
+}
+
+// PercentageFee charges a fraction of the notional, e.g. 0.001 for 0.1%.
+type PercentageFee struct {
+	Rate float64
+}
+
+func (f PercentageFee) Fee(notional money.Money) money.Money {
+	return notional.MulRate(f.Rate)
+}
+
+// FeeTier applies Rate to trades whose notional is at most UpTo. A zero UpTo
+// marks the last, unbounded tier.
+type FeeTier struct {
+	UpTo money.Money
+	Rate float64
+}
+
+// TieredFee charges the rate of the first tier the notional falls into, so
+// larger trades can be charged proportionally less.
+type TieredFee struct {
+	Tiers []FeeTier
+}
+
+func (f TieredFee) Fee(notional money.Money) money.Money {
+	for _, tier := range f.Tiers {
+		if tier.UpTo.IsZero() || notional <= tier.UpTo {
+			return notional.MulRate(tier.Rate)
+		}
+	}
+	return money.Zero
+}
+
+// MinimumFee wraps another model and never charges less than Minimum.
+type MinimumFee struct {
+	Model   FeeModel
+	Minimum money.Money
+}
+
+func (f MinimumFee) Fee(notional money.Money) money.Money {
+	return max(f.Model.Fee(notional), f.Minimum)
+}
+
+// DefaultFeeTiers is the schedule used by the "tiered" fee model.
+var DefaultFeeTiers = []FeeTier{
+	{UpTo: money.FromCents(100000), Rate: 0.005},
+	{UpTo: money.FromCents(500000), Rate: 0.0025},
+	{UpTo: money.Zero, Rate: 0.001},
+}
+
+// NewFeeModel builds a fee model by name: "none", "flat" (value is the amount
+// per trade), "percentage" (value is the rate) or "tiered" (value is unused).
+// A positive minimum wraps the model in a MinimumFee.
+func NewFeeModel(kind string, value float64, minimum float64) (FeeModel, error) {
+	if value < 0 || minimum < 0 {
+		return nil, errors.New(errors.ErrInvalidInput, "fee values cannot be negative")
+	}
+
+	var model FeeModel
+	switch kind {
+	case "", "none":
+		return NoFee{}, nil
+	case "flat":
+		model = FlatFee{Amount: money.FromFloat(value)}
+	case "percentage":
+		model = PercentageFee{Rate: value}
+	case "tiered":
+		model = TieredFee{Tiers: DefaultFeeTiers}
+	default:
+		return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("unknown fee model: %s", kind))
+	}
+
+	if minimum > 0 {
+		model = MinimumFee{Model: model, Minimum: money.FromFloat(minimum)}
+	}
+	return model, nil
+}
@@ -0,0 +1,80 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/pkg/errors"
+	"fmt"
+	"time"
+)
+
+// FlagHeadline records the headline the player thinks is fake this week,
+// replacing any earlier flag for the week.
+func (s *service) FlagHeadline(sessionID string, index int) error {
+	tx, err := s.repo.BeginTransaction(sessionID)
+	if err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	session := tx.GetSession()
+
+	currentWeek, err := getCurrentWeek(session.Status)
+	if err != nil {
+		return errors.Wrap(errors.ErrInvalidInput, "failed to get current week", err)
+	}
+
+	gmData, err := s.gmService.GetWeekData(sessionID, currentWeek)
+	if err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to get GM week data", err)
+	}
+
+	if index < 0 || index >= len(gmData.Headlines) {
+		return errors.New(errors.ErrInvalidInput, fmt.Sprintf("headline index must be between 0 and %d", len(gmData.Headlines)-1))
+	}
+
+	ensureMetadata(session)
+	flags := session.Metadata.HeadlineFlags[:0]
+	for _, flag := range session.Metadata.HeadlineFlags {
+		if flag.Week != currentWeek {
+			flags = append(flags, flag)
+		}
+	}
+	session.Metadata.HeadlineFlags = append(flags, game_session.HeadlineFlag{Week: currentWeek, Index: index})
+	session.UpdatedAt = time.Now().Format(time.RFC3339)
+
+	if err := tx.Update(session); err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to update session", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+// revealHeadlines discloses the fake headlines of a week that is over and
+// scores the player's flag for it.
+func (s *service) revealHeadlines(session *game_session.GameSession, week int) error {
+	fakes, err := s.gmService.GetFakeHeadlines(session.SessionID, week)
+	if err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to get fake headlines", err)
+	}
+
+	reveal := game_session.NewHeadlineReveal(week, fakes, session.Metadata.HeadlineFlags)
+	session.Metadata.HeadlineReveals = append(session.Metadata.HeadlineReveals, reveal)
+	return nil
+}
+
+func detectionScore(reveals []game_session.HeadlineReveal) float64 {
+	if len(reveals) == 0 {
+		return 0
+	}
+	detected := 0
+	for _, reveal := range reveals {
+		if reveal.Detected {
+			detected++
+		}
+	}
+	return float64(detected) / float64(len(reveals))
+}
@@ -0,0 +1,43 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/domain/gm_session"
+	"sort"
+	"time"
+)
+
+// newSnapshot captures the session valued at the given week's prices.
+func newSnapshot(session *game_session.GameSession, week int, gmData *gm_session.GMWeekData) *game_session.Snapshot {
+	positions := []game_session.SnapshotPosition{}
+	for ticker, holding := range session.Metadata.Holdings {
+		if holding.Quantity == 0 {
+			continue
+		}
+		price, _ := findStockPrice(gmData, ticker)
+		positions = append(positions, game_session.SnapshotPosition{
+			Ticker:   ticker,
+			Quantity: holding.Quantity,
+			Price:    price,
+			Value:    holding.Value(price),
+		})
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		return positions[i].Ticker < positions[j].Ticker
+	})
+
+	holdingsValue := calculateHoldingsValue(session.Metadata.Holdings, gmData)
+	return &game_session.Snapshot{
+		SessionID:     session.SessionID,
+		Week:          week,
+		Cash:          session.Cash,
+		HoldingsValue: holdingsValue,
+		TotalBalance:  session.Cash + holdingsValue,
+		Positions:     positions,
+		CreatedAt:     time.Now().Format(time.RFC3339),
+	}
+}
+
+func (s *service) GetHistory(sessionID string) ([]game_session.Snapshot, error) {
+	return s.repo.FindSnapshotsBySessionID(sessionID)
+}
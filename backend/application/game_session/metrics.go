@@ -0,0 +1,62 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/pkg/money"
+	"math"
+)
+
+// scoreRisk sets the risk metrics of a session from its balance at the start
+// and at the close of every week.
+func scoreRisk(session *game_session.GameSession, balances []money.Money) {
+	session.MaxDrawdown = maxDrawdown(balances)
+
+	returns := make([]float64, 0, len(balances))
+	for i := 1; i < len(balances); i++ {
+		returns = append(returns, (balances[i] - balances[i-1]).Ratio(balances[i-1]))
+	}
+
+	mean, stddev := meanAndStdDev(returns)
+	session.Volatility = stddev
+	session.SharpeRatio = 0
+	if stddev > 0 {
+		// The game has no risk-free rate, so the ratio is the mean weekly
+		// return per unit of weekly volatility.
+		session.SharpeRatio = mean / stddev
+	}
+}
+
+// maxDrawdown is the largest fall from a peak balance, as a fraction of that peak.
+func maxDrawdown(balances []money.Money) float64 {
+	var drawdown float64
+	peak := money.Zero
+	for _, balance := range balances {
+		if balance > peak {
+			peak = balance
+		}
+		if peak > 0 {
+			drawdown = math.Max(drawdown, (peak - balance).Ratio(peak))
+		}
+	}
+	return drawdown
+}
+
+func meanAndStdDev(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(values))
+
+	return mean, math.Sqrt(variance)
+}
@@ -0,0 +1,63 @@
+package game_session
+
+import (
+	"backend/pkg/money"
+	"math"
+	"testing"
+)
+
+func TestScoreRisk(t *testing.T) {
+	tests := []struct {
+		name       string
+		balances   []money.Money
+		drawdown   float64
+		volatility float64
+		sharpe     float64
+	}{
+		{
+			name:     "one snapshot",
+			balances: []money.Money{1000000},
+		},
+		{
+			name:     "all cash",
+			balances: []money.Money{1000000, 1000000, 1000000},
+		},
+		{
+			name:     "steady growth has zero variance",
+			balances: []money.Money{1000000, 1100000, 1210000},
+		},
+		{
+			name:       "gain then loss",
+			balances:   []money.Money{1000000, 1100000, 990000},
+			drawdown:   0.1,
+			volatility: 0.1,
+		},
+		{
+			name:       "recovery past the peak",
+			balances:   []money.Money{1000000, 1200000, 1080000, 1296000},
+			drawdown:   0.1,
+			volatility: math.Sqrt(0.02),
+			sharpe:     0.1 / math.Sqrt(0.02),
+		},
+		{
+			name:     "wiped out",
+			balances: []money.Money{1000000, 0},
+			drawdown: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		session := newTestSession(tt.balances[0])
+		scoreRisk(session, tt.balances)
+
+		if math.Abs(session.MaxDrawdown-tt.drawdown) > 1e-9 {
+			t.Errorf("%s: max drawdown %v, want %v", tt.name, session.MaxDrawdown, tt.drawdown)
+		}
+		if math.Abs(session.Volatility-tt.volatility) > 1e-9 {
+			t.Errorf("%s: volatility %v, want %v", tt.name, session.Volatility, tt.volatility)
+		}
+		if math.Abs(session.SharpeRatio-tt.sharpe) > 1e-9 {
+			t.Errorf("%s: Sharpe ratio %v, want %v", tt.name, session.SharpeRatio, tt.sharpe)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/domain/gm_session"
+	"backend/pkg/errors"
+	"backend/pkg/money"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+func generateOrderID() (string, error) {
+	bytes := make([]byte, 8)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+func (s *service) PlaceOrder(sessionID string, ticker string, side game_session.OrderSide, quantity int, limitPrice money.Money, expiryWeek int) (*game_session.LimitOrder, error) {
+	if !side.IsValid() {
+		return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("invalid order side: %s", side))
+	}
+	if quantity <= 0 {
+		return nil, errors.New(errors.ErrInvalidInput, "quantity must be positive")
+	}
+	if limitPrice <= 0 {
+		return nil, errors.New(errors.ErrInvalidInput, "limit price must be positive")
+	}
+
+	tx, err := s.repo.BeginTransaction(sessionID)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	session := tx.GetSession()
+
+	currentWeek, err := getCurrentWeek(session.Status)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "failed to get current week", err)
+	}
+
+	// Orders are evaluated against the prices of the days still to come, so
+	// an order expiring this week needs a trading day left in it. Room
+	// sessions only move on a week at a time.
+	finalWeek := session.Rules.Weeks
+	firstExpiryWeek := currentWeek
+	if session.Day >= game_session.TradingDaysPerWeek || session.Mode == game_session.ModeRoom {
+		firstExpiryWeek = currentWeek + 1
+	}
+	if firstExpiryWeek > finalWeek {
+		return nil, errors.New(errors.ErrInvalidInput, "no trading day is left for a limit order to fill")
+	}
+
+	if expiryWeek == 0 {
+		expiryWeek = finalWeek
+	}
+	if expiryWeek < firstExpiryWeek || expiryWeek > finalWeek {
+		return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("expiry week must be between %d and %d", firstExpiryWeek, finalWeek))
+	}
+
+	gmData, err := s.gmService.GetWeekData(sessionID, currentWeek)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to get GM week data", err)
+	}
+
+	if _, found := findStockPrice(gmData, ticker); !found {
+		return nil, errors.New(errors.ErrNotFound, fmt.Sprintf("stock %s not found in current week data", ticker))
+	}
+
+	ensureMetadata(session)
+	if side == game_session.OrderSideSell && !holdsLong(session, ticker) {
+		return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("sell orders close a long position, and none is held in %s", ticker))
+	}
+
+	orderID, err := generateOrderID()
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to generate order id", err)
+	}
+
+	order := game_session.LimitOrder{
+		ID:         orderID,
+		Ticker:     ticker,
+		Side:       side,
+		Quantity:   quantity,
+		LimitPrice: limitPrice,
+		PlacedWeek: currentWeek,
+		ExpiryWeek: expiryWeek,
+		Status:     game_session.OrderStatusOpen,
+	}
+
+	session.Metadata.Orders = append(session.Metadata.Orders, order)
+	session.UpdatedAt = time.Now().Format(time.RFC3339)
+
+	if err := tx.Update(session); err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to update session", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to commit transaction", err)
+	}
+
+	return &order, nil
+}
+
+func (s *service) GetOrders(sessionID string) ([]game_session.LimitOrder, error) {
+	session, err := s.repo.FindBySessionID(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Metadata == nil || session.Metadata.Orders == nil {
+		return []game_session.LimitOrder{}, nil
+	}
+	return session.Metadata.Orders, nil
+}
+
+func (s *service) CancelOrder(sessionID string, orderID string) error {
+	tx, err := s.repo.BeginTransaction(sessionID)
+	if err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	session := tx.GetSession()
+
+	ensureMetadata(session)
+	index := -1
+	for i := range session.Metadata.Orders {
+		if session.Metadata.Orders[i].ID == orderID {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return errors.New(errors.ErrNotFound, fmt.Sprintf("order %s not found", orderID))
+	}
+
+	order := &session.Metadata.Orders[index]
+	if order.Status != game_session.OrderStatusOpen {
+		return errors.New(errors.ErrConflict, fmt.Sprintf("order %s is already %s", orderID, order.Status))
+	}
+
+	order.Status = game_session.OrderStatusCancelled
+	session.UpdatedAt = time.Now().Format(time.RFC3339)
+
+	if err := tx.Update(session); err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to update session", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+// executeLimitOrders fills the open orders whose limit is reached at the given
+// day's prices. Sells run first so their proceeds can fund buys. Orders that
+// cannot be covered by cash or holdings stay open until they expire, after
+// the last trading day of their expiry week. Sell orders only ever close a
+// long position: one reached once the position is gone, say after its
+// stop-loss fired, is cancelled rather than left to open a short.
+func executeLimitOrders(session *game_session.GameSession, week int, day int, gmData *gm_session.GMWeekData, fees FeeModel) []*game_session.Trade {
+	// Orders left open at the end of an earlier week never saw its last day,
+	// as rooms skip the days, and must not fill at this week's prices.
+	expireOrders(session, func(order *game_session.LimitOrder) bool {
+		return order.ExpiryWeek < week
+	})
+
+	var trades []*game_session.Trade
+	for _, side := range []game_session.OrderSide{game_session.OrderSideSell, game_session.OrderSideBuy} {
+		for i := range session.Metadata.Orders {
+			order := &session.Metadata.Orders[i]
+			if order.Status != game_session.OrderStatusOpen || order.Side != side {
+				continue
+			}
+
+			price, found := findStockPrice(gmData, order.Ticker)
+			if !found || !order.Matches(price) {
+				continue
+			}
+
+			if order.Side == game_session.OrderSideSell && !holdsLong(session, order.Ticker) {
+				order.Status = game_session.OrderStatusCancelled
+				continue
+			}
+
+			var trade *game_session.Trade
+			var err error
+			if order.Side == game_session.OrderSideBuy {
+				trade, err = buyShares(session, order.Ticker, order.Quantity, price, fees)
+			} else {
+				trade, err = sellShares(session, order.Ticker, order.Quantity, price, fees)
+			}
+			if err != nil {
+				continue
+			}
+
+			order.Status = game_session.OrderStatusFilled
+			order.FilledWeek = week
+			order.FillPrice = price
+
+			trade.Week = week
+			trade.Source = game_session.TradeSourceLimitOrder
+			trades = append(trades, trade)
+		}
+	}
+
+	if day >= game_session.TradingDaysPerWeek {
+		expireOrders(session, func(order *game_session.LimitOrder) bool {
+			return order.ExpiryWeek <= week
+		})
+	}
+
+	return trades
+}
+
+func holdsLong(session *game_session.GameSession, ticker string) bool {
+	return session.Metadata.Holdings[ticker].Quantity > 0
+}
+
+func expireOpenOrders(session *game_session.GameSession) {
+	expireOrders(session, func(*game_session.LimitOrder) bool {
+		return true
+	})
+}
+
+// expireOrders expires the open orders for which expired returns true.
+func expireOrders(session *game_session.GameSession, expired func(*game_session.LimitOrder) bool) {
+	for i := range session.Metadata.Orders {
+		order := &session.Metadata.Orders[i]
+		if order.Status == game_session.OrderStatusOpen && expired(order) {
+			order.Status = game_session.OrderStatusExpired
+		}
+	}
+}
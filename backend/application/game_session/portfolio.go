@@ -0,0 +1,84 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/pkg/errors"
+	"sort"
+)
+
+// GetPortfolio values every position of the session at the current week's
+// prices. Closed positions are listed while they carry realized P&L.
+func (s *service) GetPortfolio(sessionID string) (*game_session.Portfolio, error) {
+	session, err := s.repo.FindBySessionID(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentWeek, err := getCurrentWeek(session.Status)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "failed to get current week", err)
+	}
+
+	gmData, err := s.gmService.GetWeekData(sessionID, currentWeek)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to get GM week data", err)
+	}
+	gmData = gmData.OnDay(session.Day)
+
+	ensureMetadata(session)
+	updateValuation(session, gmData)
+
+	portfolio := &game_session.Portfolio{
+		Week:          currentWeek,
+		Day:           session.Day,
+		Cash:          session.Cash,
+		HoldingsValue: session.HoldingsValue,
+		TotalBalance:  session.TotalBalance,
+		Positions:     []game_session.Position{},
+	}
+
+	insights := make(map[string]int, len(gmData.Stocks))
+	for i, insight := range gmData.Stocks {
+		insights[insight.Ticker] = i
+	}
+
+	for ticker, holding := range session.Metadata.Holdings {
+		if holding.Quantity == 0 && holding.RealizedPnL.IsZero() {
+			continue
+		}
+
+		position := game_session.Position{
+			Ticker:      ticker,
+			Quantity:    holding.Quantity,
+			RealizedPnL: holding.RealizedPnL,
+		}
+
+		if i, found := insights[ticker]; found {
+			insight := gmData.Stocks[i]
+			position.CompanyName = insight.CompanyName
+			position.Category = insight.Category
+			position.CurrentPrice = insight.Price
+		}
+
+		if holding.Quantity != 0 {
+			position.MarketValue = position.CurrentPrice.Mul(holding.Quantity)
+			position.AverageCost = holding.TotalSpent.MulDiv(1, int64(abs(holding.Quantity)))
+			if holding.IsShort() {
+				position.UnrealizedPnL = holding.TotalSpent + position.MarketValue
+			} else {
+				position.UnrealizedPnL = position.MarketValue - holding.TotalSpent
+			}
+			position.Weight = position.MarketValue.Ratio(session.TotalBalance)
+		}
+
+		portfolio.UnrealizedPnL += position.UnrealizedPnL
+		portfolio.RealizedPnL += position.RealizedPnL
+		portfolio.Positions = append(portfolio.Positions, position)
+	}
+
+	sort.Slice(portfolio.Positions, func(i, j int) bool {
+		return portfolio.Positions[i].Ticker < portfolio.Positions[j].Ticker
+	})
+
+	return portfolio, nil
+}
@@ -0,0 +1,137 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/domain/gm_session"
+	"backend/pkg/errors"
+	"backend/pkg/money"
+	"fmt"
+	"time"
+)
+
+// SetProtection attaches stop-loss/take-profit thresholds to a holding.
+// Passing zero for both thresholds removes the protection.
+func (s *service) SetProtection(sessionID string, ticker string, stopLoss money.Money, takeProfit money.Money, quantity int) error {
+	if stopLoss.IsNegative() || takeProfit.IsNegative() {
+		return errors.New(errors.ErrInvalidInput, "thresholds cannot be negative")
+	}
+	if quantity < 0 {
+		return errors.New(errors.ErrInvalidInput, "quantity cannot be negative")
+	}
+
+	tx, err := s.repo.BeginTransaction(sessionID)
+	if err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	session := tx.GetSession()
+
+	currentWeek, err := getCurrentWeek(session.Status)
+	if err != nil {
+		return errors.Wrap(errors.ErrInvalidInput, "failed to get current week", err)
+	}
+
+	ensureMetadata(session)
+	holding, exists := session.Metadata.Holdings[ticker]
+	if !exists || holding.Quantity <= 0 {
+		return errors.New(errors.ErrNotFound, fmt.Sprintf("no holdings found for stock %s", ticker))
+	}
+
+	if quantity > holding.Quantity {
+		return errors.New(errors.ErrInvalidInput, fmt.Sprintf("insufficient stocks: have %d, want to protect %d", holding.Quantity, quantity))
+	}
+
+	if stopLoss > 0 || takeProfit > 0 {
+		gmData, err := s.gmService.GetWeekData(sessionID, currentWeek)
+		if err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to get GM week data", err)
+		}
+		gmData = gmData.OnDay(session.Day)
+
+		price, found := findStockPrice(gmData, ticker)
+		if !found {
+			return errors.New(errors.ErrNotFound, fmt.Sprintf("stock %s not found in current week data", ticker))
+		}
+
+		if stopLoss > 0 && stopLoss >= price {
+			return errors.New(errors.ErrInvalidInput, fmt.Sprintf("stop-loss must be below the current price of %s", price))
+		}
+		if takeProfit > 0 && takeProfit <= price {
+			return errors.New(errors.ErrInvalidInput, fmt.Sprintf("take-profit must be above the current price of %s", price))
+		}
+	}
+
+	holding.StopLoss = stopLoss
+	holding.TakeProfit = takeProfit
+	holding.ProtectedQuantity = quantity
+	if !holding.HasProtection() {
+		holding.ClearProtection()
+	}
+	session.Metadata.Holdings[ticker] = holding
+	session.UpdatedAt = time.Now().Format(time.RFC3339)
+
+	if err := tx.Update(session); err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to update session", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+// executeProtections liquidates the holdings whose stop-loss or take-profit is
+// crossed by the given week's price. Thresholds are one-shot: once triggered
+// they are cleared from the remaining position.
+func executeProtections(session *game_session.GameSession, week int, gmData *gm_session.GMWeekData, fees FeeModel) []*game_session.Trade {
+	var trades []*game_session.Trade
+	for ticker, holding := range session.Metadata.Holdings {
+		if holding.Quantity <= 0 || !holding.HasProtection() {
+			continue
+		}
+
+		price, found := findStockPrice(gmData, ticker)
+		if !found {
+			continue
+		}
+
+		var reason game_session.ProtectionReason
+		switch {
+		case holding.StopLoss > 0 && price <= holding.StopLoss:
+			reason = game_session.ReasonStopLoss
+		case holding.TakeProfit > 0 && price >= holding.TakeProfit:
+			reason = game_session.ReasonTakeProfit
+		default:
+			continue
+		}
+
+		quantity := holding.Quantity
+		if holding.ProtectedQuantity > 0 && holding.ProtectedQuantity < quantity {
+			quantity = holding.ProtectedQuantity
+		}
+
+		trade, err := sellShares(session, ticker, quantity, price, fees)
+		if err != nil {
+			continue
+		}
+		trade.Week = week
+		trade.Source = game_session.TradeSourceFor(reason)
+		trades = append(trades, trade)
+
+		remaining := session.Metadata.Holdings[ticker]
+		remaining.ClearProtection()
+		session.Metadata.Holdings[ticker] = remaining
+
+		session.Metadata.ProtectionFills = append(session.Metadata.ProtectionFills, game_session.ProtectionFill{
+			Ticker:   ticker,
+			Week:     week,
+			Quantity: quantity,
+			Price:    price,
+			Reason:   reason,
+		})
+	}
+
+	return trades
+}
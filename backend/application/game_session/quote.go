@@ -0,0 +1,96 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/pkg/errors"
+	"fmt"
+)
+
+// Quote prices a buy or sell with the same rules as Buy and Sell, applying it
+// to a copy of the session so nothing is persisted.
+func (s *service) Quote(sessionID string, ticker string, side game_session.OrderSide, quantity int) (*game_session.TradeQuote, error) {
+	if !side.IsValid() {
+		return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("invalid order side: %s", side))
+	}
+	if quantity <= 0 {
+		return nil, errors.New(errors.ErrInvalidInput, "quantity must be positive")
+	}
+
+	session, err := s.repo.FindBySessionID(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentWeek, err := getCurrentWeek(session.Status)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "failed to get current week", err)
+	}
+
+	gmData, err := s.gmService.GetWeekData(sessionID, currentWeek)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to get GM week data", err)
+	}
+	gmData = gmData.OnDay(session.Day)
+
+	stockPrice, found := findStockPrice(gmData, ticker)
+	if !found {
+		return nil, errors.New(errors.ErrNotFound, fmt.Sprintf("stock %s not found in current week data", ticker))
+	}
+
+	projected := cloneSession(session)
+
+	var trade *game_session.Trade
+	if side == game_session.OrderSideBuy {
+		trade, err = buyShares(projected, ticker, quantity, stockPrice, s.fees)
+	} else {
+		trade, err = sellShares(projected, ticker, quantity, stockPrice, s.fees)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	updateValuation(projected, gmData)
+
+	holding := projected.Metadata.Holdings[ticker]
+	averageCost := holding.TotalSpent.MulDiv(1, int64(abs(holding.Quantity)))
+
+	return &game_session.TradeQuote{
+		Ticker:           ticker,
+		Side:             side,
+		Quantity:         quantity,
+		Price:            stockPrice,
+		Fee:              trade.Fee,
+		CashChange:       trade.CashAfter - trade.CashBefore,
+		Cash:             projected.Cash,
+		HoldingsValue:    projected.HoldingsValue,
+		TotalBalance:     projected.TotalBalance,
+		PositionQuantity: holding.Quantity,
+		AverageCost:      averageCost,
+	}, nil
+}
+
+// cloneSession copies a session deep enough for trades to be simulated on it.
+func cloneSession(session *game_session.GameSession) *game_session.GameSession {
+	clone := *session
+	metadata := game_session.SessionMetadata{}
+	if session.Metadata != nil {
+		metadata = *session.Metadata
+	}
+	metadata.Holdings = make(map[string]game_session.HoldingInfo, len(metadata.Holdings))
+	if session.Metadata != nil {
+		for ticker, holding := range session.Metadata.Holdings {
+			metadata.Holdings[ticker] = holding
+		}
+	}
+	metadata.Orders = append([]game_session.LimitOrder(nil), metadata.Orders...)
+	metadata.ProtectionFills = append([]game_session.ProtectionFill(nil), metadata.ProtectionFills...)
+	clone.Metadata = &metadata
+	return &clone
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
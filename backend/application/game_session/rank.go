@@ -0,0 +1,59 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/pkg/errors"
+	"fmt"
+)
+
+// GetRank places the finished session with the given result token on the
+// all-time leaderboard, with the entries directly above and below it.
+func (s *service) GetRank(resultToken string, metric game_session.LeaderboardMetric) (*game_session.RankResult, error) {
+	if resultToken == "" {
+		return nil, errors.New(errors.ErrInvalidInput, "result token is required")
+	}
+	if metric == "" {
+		metric = game_session.MetricReturn
+	}
+	if !metric.IsValid() {
+		return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("invalid leaderboard metric: %s", metric))
+	}
+
+	rank, total, err := s.repo.FindRankByResultToken(resultToken, metric)
+	if err != nil {
+		return nil, err
+	}
+
+	first := rank - 1
+	if first < 1 {
+		first = 1
+	}
+	sessions, err := s.repo.FindLeaderboardRange(metric, first-1, rank+1-first+1)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &game_session.RankResult{
+		Metric:     metric,
+		Rank:       rank,
+		Total:      total,
+		Percentile: 100,
+	}
+	if total > 1 {
+		result.Percentile = float64(total-int64(rank)) / float64(total-1) * 100
+	}
+
+	for i, session := range sessions {
+		entry := game_session.LeaderboardEntry{Rank: first + i, GameSession: session}
+		switch {
+		case entry.Rank < rank:
+			result.Above = &entry
+		case entry.Rank == rank:
+			result.Entry = entry
+		default:
+			result.Below = &entry
+		}
+	}
+
+	return result, nil
+}
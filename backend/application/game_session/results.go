@@ -0,0 +1,72 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/domain/gm_session"
+	"backend/pkg/errors"
+	"encoding/json"
+	"time"
+)
+
+// SessionResults is everything about a finished session: its final state and
+// score, the GM data of every week, its trades and its weekly snapshots.
+type SessionResults struct {
+	Result  game_session.GameResult  `json:"result"`
+	Weeks   []*gm_session.GMWeekData `json:"weeks"`
+	Trades  []game_session.Trade     `json:"trades"`
+	History []game_session.Snapshot  `json:"history"`
+}
+
+func newArchive(result *game_session.GameResult, weeks []*gm_session.GMWeekData) (*game_session.Archive, error) {
+	weekData, err := json.Marshal(weeks)
+	if err != nil {
+		return nil, err
+	}
+	return &game_session.Archive{
+		SessionID:   result.SessionID,
+		ResultToken: result.ResultToken,
+		Result:      *result,
+		WeekData:    weekData,
+		ArchivedAt:  time.Now().Format(time.RFC3339),
+	}, nil
+}
+
+// GetResults serves the archived results of a finished session by its result token.
+func (s *service) GetResults(resultToken string) (*SessionResults, error) {
+	if resultToken == "" {
+		return nil, errors.New(errors.ErrInvalidInput, "result token is required")
+	}
+
+	archive, err := s.repo.FindArchiveByResultToken(resultToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var weeks []*gm_session.GMWeekData
+	if err := json.Unmarshal(archive.WeekData, &weeks); err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to decode archived week data", err)
+	}
+
+	trades, err := s.repo.FindTradesBySessionID(archive.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.repo.FindSnapshotsBySessionID(archive.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sessions archived before the strategy was named were scored against
+	// the same long-only optimum.
+	if archive.Result.OptimalStrategy == "" {
+		archive.Result.OptimalStrategy = game_session.OptimalStrategyLongOnly
+	}
+
+	return &SessionResults{
+		Result:  archive.Result,
+		Weeks:   weeks,
+		Trades:  trades,
+		History: history,
+	}, nil
+}
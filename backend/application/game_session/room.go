@@ -0,0 +1,469 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/domain/gm_session"
+	"backend/domain/room"
+	"backend/pkg/errors"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	// roomCodeAlphabet leaves out characters that are easily mistaken for one
+	// another, such as O and 0 or I and 1.
+	roomCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	roomCodeLength   = 6
+	roomCodeAttempts = 5
+	maxRoomMembers   = 20
+)
+
+// CreateRoom creates a room hosted by a new session and crafts its scenario in
+// the background. It returns the host's session ID and the room's join code.
+func (s *service) CreateRoom(username string, categories []string, rules game_session.GameRules) (string, string, error) {
+	rules = rules.WithDefaults()
+	if err := rules.Validate(); err != nil {
+		return "", "", err
+	}
+
+	code, err := s.newRoomCode()
+	if err != nil {
+		return "", "", err
+	}
+
+	session, err := newSession(username, categories, rules)
+	if err != nil {
+		return "", "", err
+	}
+	session.Mode = game_session.ModeRoom
+	session.RoomCode = code
+	sessionID := session.SessionID
+
+	if err := s.repo.Save(session); err != nil {
+		return "", "", err
+	}
+
+	rm := &room.Room{
+		Code:          code,
+		HostSessionID: sessionID,
+		Status:        room.StatusCrafting,
+		Week:          1,
+		Rules:         rules,
+		Categories:    categories,
+		Members: []room.Member{{
+			SessionID: sessionID,
+			Username:  username,
+			Host:      true,
+		}},
+		WeekData: map[string]*gm_session.GMWeekData{},
+	}
+	if err := s.roomRepo.Create(rm); err != nil {
+		return "", "", err
+	}
+
+	s.taskRunner.Dispatch(func() {
+		if err := s.craftRoom(code, categories, rules); err != nil {
+			// Log the error but don't return it since this is a background task
+			fmt.Printf("Error crafting room %s: %v\n", code, err)
+		}
+	})
+
+	return sessionID, code, nil
+}
+
+// JoinRoom adds a new session to the room. Players can join until the room
+// moves past its first week.
+func (s *service) JoinRoom(code string, username string) (string, error) {
+	tx, err := s.roomRepo.BeginTransaction(normalizeRoomCode(code))
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	rm := tx.GetRoom()
+	if (rm.Status != room.StatusCrafting && rm.Status != room.StatusPlaying) || rm.Week != 1 {
+		return "", errors.New(errors.ErrConflict, "the room is no longer open to new players")
+	}
+	if len(rm.Members) >= maxRoomMembers {
+		return "", errors.New(errors.ErrConflict, fmt.Sprintf("the room is full: at most %d players", maxRoomMembers))
+	}
+
+	session, err := newSession(username, rm.Categories, rm.Rules)
+	if err != nil {
+		return "", err
+	}
+	session.Mode = game_session.ModeRoom
+	session.RoomCode = rm.Code
+	sessionID := session.SessionID
+
+	if err := s.repo.Save(session); err != nil {
+		return "", err
+	}
+	if err := tx.AddMember(room.Member{SessionID: sessionID, Username: username}); err != nil {
+		return "", err
+	}
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+
+	// A room still crafting loads every member once its scenario is ready.
+	if rm.Status == room.StatusPlaying {
+		s.taskRunner.Dispatch(func() {
+			err := s.loadRoomSession(sessionID, rm)
+			if err != nil {
+				// Log the error but don't return it since this is a background task
+				fmt.Printf("Error loading room %s for session %s: %v\n", rm.Code, sessionID, err)
+			}
+			s.publishCrafted(sessionID, err)
+		})
+	}
+
+	return sessionID, nil
+}
+
+func (s *service) GetRoom(code string) (*room.Room, error) {
+	return s.roomRepo.FindByCode(normalizeRoomCode(code))
+}
+
+// GetRoomLeaderboard ranks the room's players by their current balance.
+func (s *service) GetRoomLeaderboard(code string) ([]room.Standing, error) {
+	rm, err := s.roomRepo.FindByCode(normalizeRoomCode(code))
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.repo.FindByRoomCode(rm.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	ready := make(map[string]bool, len(rm.Members))
+	for _, member := range rm.Members {
+		ready[member.SessionID] = member.Ready
+	}
+
+	standings := make([]room.Standing, len(sessions))
+	for i, session := range sessions {
+		standings[i] = room.Standing{
+			Rank:          i + 1,
+			Username:      session.Username,
+			Cash:          session.Cash,
+			HoldingsValue: session.HoldingsValue,
+			TotalBalance:  session.TotalBalance,
+			Return:        (session.TotalBalance - session.Rules.StartingCash).Ratio(session.Rules.StartingCash),
+			Status:        session.Status,
+			Ready:         ready[session.SessionID],
+		}
+	}
+	return standings, nil
+}
+
+// SetReady marks the session's player as ready to move on. The room advances
+// as soon as every player still playing is ready.
+func (s *service) SetReady(sessionID string) (*room.Room, error) {
+	// Only a session still playing can get ready. Waiting for the others
+	// counts as playing, so it does not let the session expire meanwhile.
+	if _, err := s.repo.FindBySessionID(sessionID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Touch(sessionID); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.beginPlayingRoom(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := tx.SetReady(sessionID, true); err != nil {
+		return nil, err
+	}
+
+	rm := tx.GetRoom()
+	playing := func(member room.Member) bool { return s.memberPlaying(member, rm.Week) }
+	if rm.AllReady(playing) {
+		if err := s.advanceRoom(tx); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return tx.GetRoom(), nil
+}
+
+// AdvanceRoom moves every session of the room to the next week, or ends them
+// all in the final week, whether or not every player is ready. Only the host
+// can force the room forward, even once their own session has expired.
+func (s *service) AdvanceRoom(sessionID string) (*room.Room, error) {
+	tx, err := s.beginPlayingRoom(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if tx.GetRoom().HostSessionID != sessionID {
+		return nil, errors.New(errors.ErrForbidden, "only the host can advance the room")
+	}
+
+	if err := s.advanceRoom(tx); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return tx.GetRoom(), nil
+}
+
+// advanceRoom advances or ends every member's session still in the room's
+// week. A member whose session expired, ended or never loaded is left behind
+// rather than holding the rest of the room back.
+//
+// Each session commits on its own, so when one fails the room stays in its
+// week and the error is returned. Sessions already moved on are skipped when
+// the room is advanced again.
+func (s *service) advanceRoom(tx room.RoomTx) error {
+	rm := tx.GetRoom()
+	final := rm.Week >= rm.Rules.Weeks
+
+	for _, member := range rm.Members {
+		week, err := s.memberWeek(member)
+		if err != nil {
+			return err
+		}
+		if week != rm.Week {
+			continue
+		}
+
+		if final {
+			_, err = s.endSession(member.SessionID, true)
+		} else {
+			err = s.advanceWeek(member.SessionID, true)
+		}
+		if err != nil {
+			return errors.Wrap(errors.GetCode(err), fmt.Sprintf("failed to advance %s in room %s", member.Username, rm.Code), err)
+		}
+	}
+
+	if final {
+		rm.Status = room.StatusFinished
+	} else {
+		rm.Week++
+	}
+
+	if err := tx.ResetReady(); err != nil {
+		return err
+	}
+	return tx.Update(rm)
+}
+
+// craftRoom crafts the room's scenario once and loads it into every member's
+// session. The stocks and daily prices are seeded by the room code.
+func (s *service) craftRoom(code string, categories []string, rules game_session.GameRules) error {
+	s.publishRoomProgress(code, game_session.StepCategories)
+	finalCategories, err := s.finalizeCategories(categories)
+	if err != nil {
+		return s.failRoom(code, err)
+	}
+
+	s.publishRoomProgress(code, game_session.StepStocks)
+	stocks, err := s.stockRepo.PickStocksForSession(finalCategories, rules.StocksPerCategory, code)
+	if err != nil {
+		return s.failRoom(code, fmt.Errorf("failed to pick stocks: %w", err))
+	}
+
+	s.publishRoomProgress(code, game_session.StepScenario)
+	gmData, err := s.aiModel.GetGMResponse(context.Background(), finalCategories, stocks, rules)
+	if err != nil {
+		return s.failRoom(code, fmt.Errorf("failed to get GM response: %w", err))
+	}
+
+	tagCategories(gmData, stocks)
+
+	if err := s.gmService.PrepareWeekData(code, gmData, rules); err != nil {
+		return s.failRoom(code, fmt.Errorf("failed to prepare GM week data: %w", err))
+	}
+
+	tx, err := s.roomRepo.BeginTransaction(code)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rm := tx.GetRoom()
+	rm.Categories = finalCategories
+	rm.WeekData = gmData
+	rm.Status = room.StatusPlaying
+	if err := tx.Update(rm); err != nil {
+		return err
+	}
+	// Members joining once the room is playing load the scenario themselves,
+	// so only the members seen under the lock are loaded here.
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, member := range rm.Members {
+		err := s.loadRoomSession(member.SessionID, rm)
+		if err != nil {
+			fmt.Printf("Error loading room %s for session %s: %v\n", code, member.SessionID, err)
+		}
+		s.publishCrafted(member.SessionID, err)
+	}
+	return nil
+}
+
+// failRoom marks the room and its members' sessions as failed, returning the
+// error that made crafting fail.
+func (s *service) failRoom(code string, cause error) error {
+	tx, err := s.roomRepo.BeginTransaction(code)
+	if err != nil {
+		return fmt.Errorf("failed to find room after crafting error: %w", err)
+	}
+	defer tx.Rollback()
+
+	rm := tx.GetRoom()
+	rm.Status = room.StatusFailed
+	if err := tx.Update(rm); err != nil {
+		return fmt.Errorf("failed to update room status after crafting error: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to update room status after crafting error: %w", err)
+	}
+
+	for _, member := range rm.Members {
+		if err := s.repo.UpdateGameCraftingStatus(member.SessionID, false); err != nil {
+			fmt.Printf("Error updating session %s status in room %s: %v\n", member.SessionID, code, err)
+		}
+		s.publishCrafted(member.SessionID, cause)
+	}
+	return cause
+}
+
+// publishRoomProgress announces the crafting step to every member of the room.
+func (s *service) publishRoomProgress(code string, step game_session.CraftingStep) {
+	rm, err := s.roomRepo.FindByCode(code)
+	if err != nil {
+		fmt.Printf("Error finding room %s to publish progress: %v\n", code, err)
+		return
+	}
+	for _, member := range rm.Members {
+		s.publishProgress(member.SessionID, step)
+	}
+}
+
+// loadRoomSession gives the session its own copy of the room's scenario, as
+// saving the week data strips the fake headline markers from it.
+func (s *service) loadRoomSession(sessionID string, rm *room.Room) error {
+	s.publishProgress(sessionID, game_session.StepSaving)
+	if err := s.repo.SetCategories(sessionID, rm.Categories); err != nil {
+		return fmt.Errorf("failed to save categories: %w", err)
+	}
+
+	weekData, err := copyWeekData(rm.WeekData)
+	if err == nil {
+		err = s.gmService.SaveGMWeekData(sessionID, weekData, rm.Rules)
+	}
+	if err != nil {
+		if updateErr := s.repo.UpdateGameCraftingStatus(sessionID, false); updateErr != nil {
+			return fmt.Errorf("failed to update session status after save error: %w", updateErr)
+		}
+		return fmt.Errorf("failed to save GM week data: %w", err)
+	}
+
+	if err := s.repo.UpdateGameCraftingStatus(sessionID, true); err != nil {
+		return fmt.Errorf("failed to update session status to week1: %w", err)
+	}
+
+	return nil
+}
+
+// beginPlayingRoom locks the playing room the session is a member of. The
+// room is found through the membership rather than the session, which may
+// have expired.
+func (s *service) beginPlayingRoom(sessionID string) (room.RoomTx, error) {
+	code, err := s.roomRepo.FindCodeBySessionID(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.roomRepo.BeginTransaction(code)
+	if err != nil {
+		return nil, err
+	}
+	if status := tx.GetRoom().Status; status != room.StatusPlaying {
+		tx.Rollback()
+		return nil, errors.New(errors.ErrConflict, fmt.Sprintf("the room is %s, not playing", status))
+	}
+	return tx, nil
+}
+
+// memberPlaying reports whether the member's session is playing the room's
+// week, as opposed to still loading, failed, expired, ended or left behind.
+func (s *service) memberPlaying(member room.Member, roomWeek int) bool {
+	week, err := s.memberWeek(member)
+	return err == nil && week == roomWeek
+}
+
+// memberWeek returns the week the member's session is playing, or 0 when it
+// is not playing any.
+func (s *service) memberWeek(member room.Member) (int, error) {
+	session, err := s.repo.FindBySessionID(member.SessionID)
+	if code := errors.GetCode(err); code == errors.ErrNotAvailable || code == errors.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	week, ok := session.Status.Week()
+	if !ok {
+		return 0, nil
+	}
+	return week, nil
+}
+
+// newRoomCode generates a join code not used by any room yet.
+func (s *service) newRoomCode() (string, error) {
+	size := big.NewInt(int64(len(roomCodeAlphabet)))
+	for attempt := 0; attempt < roomCodeAttempts; attempt++ {
+		code := make([]byte, roomCodeLength)
+		for i := range code {
+			n, err := rand.Int(rand.Reader, size)
+			if err != nil {
+				return "", errors.Wrap(errors.ErrInternal, "failed to generate room code", err)
+			}
+			code[i] = roomCodeAlphabet[n.Int64()]
+		}
+
+		_, err := s.roomRepo.FindByCode(string(code))
+		if errors.GetCode(err) == errors.ErrNotFound {
+			return string(code), nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", errors.New(errors.ErrInternal, "failed to generate an unused room code")
+}
+
+func normalizeRoomCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func copyWeekData(weekData map[string]*gm_session.GMWeekData) (map[string]*gm_session.GMWeekData, error) {
+	data, err := json.Marshal(weekData)
+	if err != nil {
+		return nil, err
+	}
+	var copied map[string]*gm_session.GMWeekData
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
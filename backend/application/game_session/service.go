@@ -3,38 +3,70 @@ package game_session
 import (
 	gmsvc "backend/application/gm_session"
 	"backend/domain/category"
+	"backend/domain/challenge"
 	"backend/domain/game_session"
 	"backend/domain/gm_session"
+	"backend/domain/room"
 	"backend/domain/stock"
 	"backend/infrastructure/taskrunner"
 	"backend/pkg/errors"
+	"backend/pkg/money"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"time"
 )
 
 type Service interface {
-	Create(username string, categories []string) (string, error)
+	Create(username string, categories []string, rules game_session.GameRules) (string, error)
+	StartChallenge(username string) (string, error)
+	GetChallenge(date string) (*challenge.DailyChallenge, error)
+	CreateRoom(username string, categories []string, rules game_session.GameRules) (string, string, error)
+	JoinRoom(code string, username string) (string, error)
+	GetRoom(code string) (*room.Room, error)
+	GetRoomLeaderboard(code string) ([]room.Standing, error)
+	SetReady(sessionID string) (*room.Room, error)
+	AdvanceRoom(sessionID string) (*room.Room, error)
 	GetState(sessionID string) (*game_session.GameSession, error)
-	GetLeaderboard() ([]game_session.GameSession, error)
+	StreamEvents(ctx context.Context, sessionID string) (<-chan game_session.Event, error)
+	IssueStreamTicket(sessionID string) (string, error)
+	RedeemStreamTicket(ticket string) (string, error)
+	GetPortfolio(sessionID string) (*game_session.Portfolio, error)
+	GetLeaderboard(query game_session.LeaderboardQuery) (*game_session.LeaderboardPage, error)
+	GetRank(resultToken string, metric game_session.LeaderboardMetric) (*game_session.RankResult, error)
 	Buy(sessionID string, ticker string, quantity int) error
 	Sell(sessionID string, ticker string, quantity int) error
+	Quote(sessionID string, ticker string, side game_session.OrderSide, quantity int) (*game_session.TradeQuote, error)
+	ExecuteBatch(sessionID string, orders []game_session.MarketOrder) ([]game_session.Trade, error)
 	AdvanceWeek(sessionID string) error
-	EndSession(sessionID string) (*game_session.GameSession, error)
-	SaveGMWeekData(sessionID string, gmData map[string]*gm_session.GMWeekData) error
+	AdvanceDay(sessionID string) error
+	PlaceOrder(sessionID string, ticker string, side game_session.OrderSide, quantity int, limitPrice money.Money, expiryWeek int) (*game_session.LimitOrder, error)
+	GetOrders(sessionID string) ([]game_session.LimitOrder, error)
+	CancelOrder(sessionID string, orderID string) error
+	GetTrades(sessionID string) ([]game_session.Trade, error)
+	GetHistory(sessionID string) ([]game_session.Snapshot, error)
+	GetResults(resultToken string) (*SessionResults, error)
+	SetProtection(sessionID string, ticker string, stopLoss money.Money, takeProfit money.Money, quantity int) error
+	FlagHeadline(sessionID string, index int) error
+	EndSession(sessionID string) (*game_session.GameResult, error)
+	SaveGMWeekData(sessionID string, gmData map[string]*gm_session.GMWeekData, rules game_session.GameRules) error
 	GetWeekData(sessionID string, week int) (*gm_session.GMWeekData, error)
-	CraftTheGame(sessionID string, categories []string) error
+	CraftTheGame(sessionID string, categories []string, rules game_session.GameRules) error
 }
 
 type service struct {
-	repo         game_session.Repository
-	stockRepo    stock.Repository
-	categoryRepo category.Repository
-	aiModel      gm_session.AI
-	gmService    gmsvc.Service
-	taskRunner   *taskrunner.TaskRunner
+	repo          game_session.Repository
+	stockRepo     stock.Repository
+	categoryRepo  category.Repository
+	aiModel       gm_session.AI
+	gmService     gmsvc.Service
+	taskRunner    *taskrunner.TaskRunner
+	fees          FeeModel
+	challengeRepo challenge.Repository
+	roomRepo      room.Repository
+	events        game_session.EventBus
 }
 
 func NewService(
@@ -44,17 +76,30 @@ func NewService(
 	aiModel gm_session.AI,
 	gmService gmsvc.Service,
 	taskRunner *taskrunner.TaskRunner,
+	fees FeeModel,
+	challengeRepo challenge.Repository,
+	roomRepo room.Repository,
+	events game_session.EventBus,
 ) Service {
 	return &service{
-		repo:         repo,
-		stockRepo:    stockRepo,
-		categoryRepo: categoryRepo,
-		aiModel:      aiModel,
-		gmService:    gmService,
-		taskRunner:   taskRunner,
+		repo:          repo,
+		stockRepo:     stockRepo,
+		categoryRepo:  categoryRepo,
+		aiModel:       aiModel,
+		gmService:     gmService,
+		taskRunner:    taskRunner,
+		fees:          fees,
+		challengeRepo: challengeRepo,
+		roomRepo:      roomRepo,
+		events:        events,
 	}
 }
 
+const (
+	defaultLeaderboardPageSize = 10
+	maxLeaderboardPageSize     = 100
+)
+
 func generateSecureToken() (string, error) {
 	bytes := make([]byte, 32) // 32 bytes will give us a 64 character hex string
 	if _, err := rand.Read(bytes); err != nil {
@@ -71,49 +116,165 @@ func (s *service) GetState(sessionID string) (*game_session.GameSession, error)
 	return session, nil
 }
 
-func (s *service) GetLeaderboard() ([]game_session.GameSession, error) {
-	return s.repo.FindLeaderboardTop10(1, 10)
+func (s *service) GetLeaderboard(query game_session.LeaderboardQuery) (*game_session.LeaderboardPage, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PageSize == 0 {
+		query.PageSize = defaultLeaderboardPageSize
+	}
+	if query.Metric == "" {
+		query.Metric = game_session.MetricReturn
+	}
+	if query.Window == "" {
+		query.Window = game_session.WindowAll
+	}
+
+	if query.Page < 1 {
+		return nil, errors.New(errors.ErrInvalidInput, "page must be positive")
+	}
+	if query.PageSize < 1 || query.PageSize > maxLeaderboardPageSize {
+		return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("page size must be between 1 and %d", maxLeaderboardPageSize))
+	}
+	if !query.Metric.IsValid() {
+		return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("invalid leaderboard metric: %s", query.Metric))
+	}
+	if !query.Window.IsValid() {
+		return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("invalid leaderboard window: %s", query.Window))
+	}
+	if query.Difficulty != "" && !query.Difficulty.IsValid() {
+		return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("invalid difficulty: %s", query.Difficulty))
+	}
+	if query.ChallengeDate != "" {
+		if _, err := time.Parse(challenge.DateLayout, query.ChallengeDate); err != nil {
+			return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("invalid challenge date: %s", query.ChallengeDate))
+		}
+	}
+
+	sessions, total, err := s.repo.FindLeaderboard(query)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]game_session.LeaderboardEntry, len(sessions))
+	for i, session := range sessions {
+		entries[i] = game_session.LeaderboardEntry{
+			Rank:        (query.Page-1)*query.PageSize + i + 1,
+			GameSession: session,
+		}
+	}
+
+	return &game_session.LeaderboardPage{
+		Entries:  entries,
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}, nil
 }
 
-func (s *service) Create(username string, categories []string) (string, error) {
-	sessionID, err := generateSecureToken()
+func (s *service) Create(username string, categories []string, rules game_session.GameRules) (string, error) {
+	rules = rules.WithDefaults()
+	if err := rules.Validate(); err != nil {
+		return "", err
+	}
+
+	session, err := newSession(username, categories, rules)
 	if err != nil {
 		return "", err
 	}
+	sessionID := session.SessionID
+
+	if err := s.repo.Save(session); err != nil {
+		return "", err
+	}
+
+	s.taskRunner.Dispatch(func() {
+		err := s.CraftTheGame(sessionID, categories, rules)
+		if err != nil {
+			// Log the error but don't return it since this is a background task
+			fmt.Printf("Error crafting game for session %s: %v\n", sessionID, err)
+		}
+		s.publishCrafted(sessionID, err)
+	})
+
+	return sessionID, nil
+}
+
+// newSession builds a classic session about to be crafted.
+func newSession(username string, categories []string, rules game_session.GameRules) (*game_session.GameSession, error) {
+	sessionID, err := generateSecureToken()
+	if err != nil {
+		return nil, err
+	}
 
-	initialCash := 10000.00
-	session := &game_session.GameSession{
+	return &game_session.GameSession{
 		SessionID:     sessionID,
 		Username:      username,
-		Cash:          initialCash,
-		HoldingsValue: 0.00,
-		TotalBalance:  initialCash,
+		Cash:          rules.StartingCash,
+		HoldingsValue: money.Zero,
+		TotalBalance:  rules.StartingCash,
+		Rules:         rules,
+		Categories:    categories,
+		Mode:          game_session.ModeClassic,
 		Status:        game_session.StatusStarting,
+		Day:           1,
 		CreatedAt:     time.Now().Format(time.RFC3339),
 		UpdatedAt:     time.Now().Format(time.RFC3339),
 		Metadata: &game_session.SessionMetadata{
 			Holdings: make(map[string]game_session.HoldingInfo),
 		},
+	}, nil
+}
+
+func (s *service) CraftTheGame(sessionID string, categories []string, rules game_session.GameRules) error {
+	s.publishProgress(sessionID, game_session.StepCategories)
+	finalCategories, err := s.finalizeCategories(categories)
+	if err != nil {
+		return err
 	}
 
-	if err := s.repo.Save(session); err != nil {
-		return "", err
+	if err := s.repo.SetCategories(sessionID, finalCategories); err != nil {
+		return fmt.Errorf("failed to save categories: %w", err)
 	}
 
-	s.taskRunner.Dispatch(func() {
-		if err := s.CraftTheGame(sessionID, categories); err != nil {
-			// Log the error but don't return it since this is a background task
-			fmt.Printf("Error crafting game for session %s: %v\n", sessionID, err)
+	s.publishProgress(sessionID, game_session.StepStocks)
+	stocks, err := s.stockRepo.PickStocksForSession(finalCategories, rules.StocksPerCategory, "")
+	if err != nil {
+		return fmt.Errorf("failed to pick stocks: %w", err)
+	}
+
+	s.publishProgress(sessionID, game_session.StepScenario)
+	gmData, err := s.aiModel.GetGMResponse(context.Background(), finalCategories, stocks, rules)
+	if err != nil {
+		if updateErr := s.repo.UpdateGameCraftingStatus(sessionID, false); updateErr != nil {
+			return fmt.Errorf("failed to update session status after AI error: %w", updateErr)
 		}
-	})
+		return fmt.Errorf("failed to get GM response: %w", err)
+	}
 
-	return sessionID, nil
+	tagCategories(gmData, stocks)
+
+	s.publishProgress(sessionID, game_session.StepSaving)
+	if err := s.gmService.SaveGMWeekData(sessionID, gmData, rules); err != nil {
+		if updateErr := s.repo.UpdateGameCraftingStatus(sessionID, false); updateErr != nil {
+			return fmt.Errorf("failed to update session status after save error: %w", updateErr)
+		}
+		return fmt.Errorf("failed to save GM week data: %w", err)
+	}
+
+	if err := s.repo.UpdateGameCraftingStatus(sessionID, true); err != nil {
+		return fmt.Errorf("failed to update session status to week1: %w", err)
+	}
+
+	return nil
 }
 
-func (s *service) CraftTheGame(sessionID string, categories []string) error {
+// finalizeCategories keeps the requested categories that exist and tops them
+// up with others until there are as many as a session plays.
+func (s *service) finalizeCategories(categories []string) ([]string, error) {
 	allCategories, err := s.categoryRepo.FindAll()
 	if err != nil {
-		return fmt.Errorf("failed to get categories: %w", err)
+		return nil, fmt.Errorf("failed to get categories: %w", err)
 	}
 
 	validSet := make(map[string]struct{})
@@ -124,7 +285,7 @@ func (s *service) CraftTheGame(sessionID string, categories []string) error {
 	}
 
 	finalSet := make(map[string]struct{})
-	finalCategories := make([]string, 0, 3)
+	finalCategories := make([]string, 0, game_session.CategoriesPerSession)
 
 	for _, cat := range categories {
 		if cat == "Trending" || cat == "Recent" {
@@ -139,7 +300,7 @@ func (s *service) CraftTheGame(sessionID string, categories []string) error {
 	}
 
 	for _, candidate := range validList {
-		if len(finalCategories) == 3 {
+		if len(finalCategories) == game_session.CategoriesPerSession {
 			break
 		}
 		if _, exists := finalSet[candidate]; !exists {
@@ -148,52 +309,165 @@ func (s *service) CraftTheGame(sessionID string, categories []string) error {
 		}
 	}
 
-	if len(finalCategories) != 3 {
-		return fmt.Errorf("could not finalize 3 valid categories")
+	if len(finalCategories) != game_session.CategoriesPerSession {
+		return nil, fmt.Errorf("could not finalize %d valid categories", game_session.CategoriesPerSession)
 	}
 
-	stocks, err := s.stockRepo.PickStocksForSession(finalCategories)
-	if err != nil {
-		return fmt.Errorf("failed to pick stocks: %w", err)
+	return finalCategories, nil
+}
+
+// tagCategories copies the category of each picked stock onto the GM's weekly
+// insights, which the AI is not asked to return.
+func tagCategories(gmData map[string]*gm_session.GMWeekData, stocks []stock.Stock) {
+	categories := make(map[string]string, len(stocks))
+	for _, st := range stocks {
+		categories[st.Ticker] = st.Category
 	}
 
-	gmData, err := s.aiModel.GetGMResponse(context.Background(), finalCategories, stocks)
-	if err != nil {
-		if updateErr := s.repo.UpdateGameCraftingStatus(sessionID, false); updateErr != nil {
-			return fmt.Errorf("failed to update session status after AI error: %w", updateErr)
+	for _, weekData := range gmData {
+		if weekData == nil {
+			continue
+		}
+		for i := range weekData.Stocks {
+			weekData.Stocks[i].Category = categories[weekData.Stocks[i].Ticker]
 		}
-		return fmt.Errorf("failed to get GM response: %w", err)
 	}
+}
 
-	if err := s.gmService.SaveGMWeekData(sessionID, gmData); err != nil {
-		if updateErr := s.repo.UpdateGameCraftingStatus(sessionID, false); updateErr != nil {
-			return fmt.Errorf("failed to update session status after save error: %w", updateErr)
+func getCurrentWeek(status game_session.GameSessionStatus) (int, error) {
+	week, ok := status.Week()
+	if !ok {
+		return 0, fmt.Errorf("invalid game status for trading: %s", status)
+	}
+	return week, nil
+}
+
+func findStockPrice(gmData *gm_session.GMWeekData, ticker string) (money.Money, bool) {
+	for _, stock := range gmData.Stocks {
+		if stock.Ticker == ticker {
+			return stock.Price, true
 		}
-		return fmt.Errorf("failed to save GM week data: %w", err)
 	}
+	return 0, false
+}
 
-	if err := s.repo.UpdateGameCraftingStatus(sessionID, true); err != nil {
-		return fmt.Errorf("failed to update session status to week1: %w", err)
+func calculateHoldingsValue(holdings map[string]game_session.HoldingInfo, gmData *gm_session.GMWeekData) money.Money {
+	holdingsValue := money.Zero
+	for ticker, holding := range holdings {
+		if price, ok := findStockPrice(gmData, ticker); ok {
+			holdingsValue += holding.Value(price)
+		}
 	}
+	return holdingsValue
+}
 
-	return nil
+func ensureMetadata(session *game_session.GameSession) {
+	if session.Metadata == nil {
+		session.Metadata = &game_session.SessionMetadata{}
+	}
+	if session.Metadata.Holdings == nil {
+		session.Metadata.Holdings = make(map[string]game_session.HoldingInfo)
+	}
 }
 
-func getCurrentWeek(status game_session.GameSessionStatus) (int, error) {
-	switch status {
-	case game_session.StatusWeek1:
-		return 1, nil
-	case game_session.StatusWeek2:
-		return 2, nil
-	case game_session.StatusWeek3:
-		return 3, nil
-	case game_session.StatusWeek4:
-		return 4, nil
-	case game_session.StatusWeek5:
-		return 5, nil
-	default:
-		return 0, fmt.Errorf("invalid game status for trading: %s", status)
+// newTrade builds the ledger entry for a fill that has just been applied to
+// the session. Callers fill in the week and source.
+func newTrade(session *game_session.GameSession, ticker string, side game_session.OrderSide, quantity int, price money.Money, fee money.Money, cashBefore money.Money) *game_session.Trade {
+	return &game_session.Trade{
+		SessionID:  session.SessionID,
+		Ticker:     ticker,
+		Side:       side,
+		Quantity:   quantity,
+		Price:      price,
+		Fee:        fee,
+		CashBefore: cashBefore,
+		CashAfter:  session.Cash,
+		CreatedAt:  time.Now().Format(time.RFC3339),
+	}
+}
+
+// buyShares buys into a long position, or covers an open short one. The fee
+// is added to the cost basis of the position.
+func buyShares(session *game_session.GameSession, ticker string, quantity int, price money.Money, fees FeeModel) (*game_session.Trade, error) {
+	ensureMetadata(session)
+	cashBefore := session.Cash
+	fee := fees.Fee(price.Mul(quantity))
+
+	holding, exists := session.Metadata.Holdings[ticker]
+	if exists && holding.IsShort() {
+		if err := coverShort(session, ticker, quantity, price, fee); err != nil {
+			return nil, err
+		}
+		return newTrade(session, ticker, game_session.OrderSideBuy, quantity, price, fee, cashBefore), nil
+	}
+
+	totalCost := price.Mul(quantity) + fee
+
+	if totalCost > session.Cash {
+		return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("insufficient funds: need %s, have %s", totalCost, session.Cash))
+	}
+
+	if exists {
+		holding.Quantity += quantity
+		holding.TotalSpent += totalCost
+	} else {
+		holding = game_session.HoldingInfo{
+			Quantity:   quantity,
+			TotalSpent: totalCost,
+		}
 	}
+	session.Metadata.Holdings[ticker] = holding
+
+	session.Cash -= totalCost
+	session.FeesPaid += fee
+	return newTrade(session, ticker, game_session.OrderSideBuy, quantity, price, fee, cashBefore), nil
+}
+
+// sellShares sells from a long position. Selling a stock that is not held
+// opens, or adds to, a short position instead.
+func sellShares(session *game_session.GameSession, ticker string, quantity int, price money.Money, fees FeeModel) (*game_session.Trade, error) {
+	ensureMetadata(session)
+	cashBefore := session.Cash
+	fee := fees.Fee(price.Mul(quantity))
+
+	holding, exists := session.Metadata.Holdings[ticker]
+	if !exists || holding.Quantity <= 0 {
+		if err := openShort(session, ticker, quantity, price, fee); err != nil {
+			return nil, err
+		}
+		return newTrade(session, ticker, game_session.OrderSideSell, quantity, price, fee, cashBefore), nil
+	}
+
+	if holding.Quantity < quantity {
+		return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("insufficient stocks: have %d, want to sell %d", holding.Quantity, quantity))
+	}
+
+	saleProceeds := price.Mul(quantity) - fee
+	if (saleProceeds + session.Cash).IsNegative() {
+		return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("insufficient funds to pay the %s fee", fee))
+	}
+
+	// The sold shares take their pro rata share of the cost basis; rounding
+	// stays with the remaining shares so selling everything clears it exactly.
+	basis := holding.TotalSpent.MulDiv(int64(quantity), int64(holding.Quantity))
+	holding.TotalSpent -= basis
+	holding.RealizedPnL += saleProceeds - basis
+	holding.Quantity -= quantity
+	if holding.Quantity == 0 {
+		holding.ClearProtection()
+	} else if holding.ProtectedQuantity > holding.Quantity {
+		holding.ProtectedQuantity = holding.Quantity
+	}
+	session.Metadata.Holdings[ticker] = holding
+	session.Cash += saleProceeds
+	session.FeesPaid += fee
+	return newTrade(session, ticker, game_session.OrderSideSell, quantity, price, fee, cashBefore), nil
+}
+
+func updateValuation(session *game_session.GameSession, gmData *gm_session.GMWeekData) {
+	session.HoldingsValue = calculateHoldingsValue(session.Metadata.Holdings, gmData)
+	session.TotalBalance = session.Cash + session.HoldingsValue
+	session.UpdatedAt = time.Now().Format(time.RFC3339)
 }
 
 func (s *service) Buy(sessionID string, ticker string, quantity int) error {
@@ -218,59 +492,28 @@ func (s *service) Buy(sessionID string, ticker string, quantity int) error {
 	if err != nil {
 		return errors.Wrap(errors.ErrInternal, "failed to get GM week data", err)
 	}
+	gmData = gmData.OnDay(session.Day)
 
-	var stockPrice float64
-	found := false
-	for _, stock := range gmData.Stocks {
-		if stock.Ticker == ticker {
-			stockPrice = stock.Price
-			found = true
-			break
-		}
-	}
+	stockPrice, found := findStockPrice(gmData, ticker)
 	if !found {
 		return errors.New(errors.ErrNotFound, fmt.Sprintf("stock %s not found in current week data", ticker))
 	}
 
-	totalCost := stockPrice * float64(quantity)
-
-	if totalCost > session.Cash {
-		return errors.New(errors.ErrInvalidInput, fmt.Sprintf("insufficient funds: need %.2f, have %.2f", totalCost, session.Cash))
+	trade, err := buyShares(session, ticker, quantity, stockPrice, s.fees)
+	if err != nil {
+		return err
 	}
 
-	if session.Metadata == nil {
-		session.Metadata = &game_session.SessionMetadata{
-			Holdings: make(map[string]game_session.HoldingInfo),
-		}
-	}
+	updateValuation(session, gmData)
 
-	holding, exists := session.Metadata.Holdings[ticker]
-	if exists {
-		holding.Quantity += quantity
-		holding.TotalSpent += totalCost
-	} else {
-		holding = game_session.HoldingInfo{
-			Quantity:   quantity,
-			TotalSpent: totalCost,
-		}
+	// The trade is recorded before the session is updated because Update also
+	// writes the holdings to Redis, which the rollback cannot undo.
+	trade.Week = currentWeek
+	trade.Day = session.Day
+	trade.Source = game_session.TradeSourceManual
+	if err := tx.RecordTrade(trade); err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to record trade", err)
 	}
-	session.Metadata.Holdings[ticker] = holding
-
-	session.Cash -= totalCost
-
-	holdingsValue := 0.0
-	for ticker, holding := range session.Metadata.Holdings {
-		for _, stock := range gmData.Stocks {
-			if stock.Ticker == ticker {
-				holdingsValue += float64(holding.Quantity) * stock.Price
-				break
-			}
-		}
-	}
-
-	session.HoldingsValue = holdingsValue
-	session.TotalBalance = session.Cash + session.HoldingsValue
-	session.UpdatedAt = time.Now().Format(time.RFC3339)
 
 	if err := tx.Update(session); err != nil {
 		return errors.Wrap(errors.ErrInternal, "failed to update session", err)
@@ -296,19 +539,6 @@ func (s *service) Sell(sessionID string, ticker string, quantity int) error {
 
 	session := tx.GetSession()
 
-	if session.Metadata == nil || session.Metadata.Holdings == nil {
-		return errors.New(errors.ErrNotFound, "no holdings found")
-	}
-
-	holding, exists := session.Metadata.Holdings[ticker]
-	if !exists {
-		return errors.New(errors.ErrNotFound, fmt.Sprintf("no holdings found for stock %s", ticker))
-	}
-
-	if holding.Quantity < quantity {
-		return errors.New(errors.ErrInvalidInput, fmt.Sprintf("insufficient stocks: have %d, want to sell %d", holding.Quantity, quantity))
-	}
-
 	currentWeek, err := getCurrentWeek(session.Status)
 	if err != nil {
 		return errors.Wrap(errors.ErrInvalidInput, "failed to get current week", err)
@@ -318,42 +548,29 @@ func (s *service) Sell(sessionID string, ticker string, quantity int) error {
 	if err != nil {
 		return errors.Wrap(errors.ErrInternal, "failed to get GM week data", err)
 	}
+	gmData = gmData.OnDay(session.Day)
 
-	var stockPrice float64
-	found := false
-	for _, stock := range gmData.Stocks {
-		if stock.Ticker == ticker {
-			stockPrice = stock.Price
-			found = true
-			break
-		}
-	}
+	stockPrice, found := findStockPrice(gmData, ticker)
 	if !found {
 		return errors.New(errors.ErrNotFound, fmt.Sprintf("stock %s not found in current week data", ticker))
 	}
 
-	saleProceeds := stockPrice * float64(quantity)
+	trade, err := sellShares(session, ticker, quantity, stockPrice, s.fees)
+	if err != nil {
+		return err
+	}
 
-	holding.Quantity -= quantity
-	spentPerShare := holding.TotalSpent / float64(holding.Quantity+quantity)
-	holding.TotalSpent -= spentPerShare * float64(quantity)
-	session.Metadata.Holdings[ticker] = holding
-	session.Cash += saleProceeds
+	updateValuation(session, gmData)
 
-	holdingsValue := 0.0
-	for ticker, holding := range session.Metadata.Holdings {
-		for _, stock := range gmData.Stocks {
-			if stock.Ticker == ticker {
-				holdingsValue += float64(holding.Quantity) * stock.Price
-				break
-			}
-		}
+	// The trade is recorded before the session is updated because Update also
+	// writes the holdings to Redis, which the rollback cannot undo.
+	trade.Week = currentWeek
+	trade.Day = session.Day
+	trade.Source = game_session.TradeSourceManual
+	if err := tx.RecordTrade(trade); err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to record trade", err)
 	}
 
-	session.HoldingsValue = holdingsValue
-	session.TotalBalance = session.Cash + session.HoldingsValue
-	session.UpdatedAt = time.Now().Format(time.RFC3339)
-
 	if err := tx.Update(session); err != nil {
 		return errors.Wrap(errors.ErrInternal, "failed to update session", err)
 	}
@@ -366,6 +583,12 @@ func (s *service) Sell(sessionID string, ticker string, quantity int) error {
 }
 
 func (s *service) AdvanceWeek(sessionID string) error {
+	return s.advanceWeek(sessionID, false)
+}
+
+// advanceWeek moves the session to the next week. Sessions playing in a room
+// only advance along with their room.
+func (s *service) advanceWeek(sessionID string, byRoom bool) error {
 	tx, err := s.repo.BeginTransaction(sessionID)
 	if err != nil {
 		return errors.Wrap(errors.ErrInternal, "failed to begin transaction", err)
@@ -373,50 +596,61 @@ func (s *service) AdvanceWeek(sessionID string) error {
 	defer tx.Rollback()
 
 	session := tx.GetSession()
+	if session.Mode == game_session.ModeRoom && !byRoom {
+		return errors.New(errors.ErrConflict, "the session advances along with its room")
+	}
 
 	currentWeek, err := getCurrentWeek(session.Status)
 	if err != nil {
 		return errors.Wrap(errors.ErrInvalidInput, "failed to get current week", err)
 	}
 
-	if currentWeek >= 5 {
-		return errors.New(errors.ErrInvalidInput, "cannot advance beyond week 5")
+	if currentWeek >= session.Rules.Weeks {
+		return errors.New(errors.ErrInvalidInput, fmt.Sprintf("cannot advance beyond week %d", session.Rules.Weeks))
 	}
 
-	var nextStatus game_session.GameSessionStatus
-	switch session.Status {
-	case game_session.StatusWeek1:
-		nextStatus = game_session.StatusWeek2
-	case game_session.StatusWeek2:
-		nextStatus = game_session.StatusWeek3
-	case game_session.StatusWeek3:
-		nextStatus = game_session.StatusWeek4
-	case game_session.StatusWeek4:
-		nextStatus = game_session.StatusWeek5
-	default:
-		return errors.New(errors.ErrInvalidInput, fmt.Sprintf("invalid game status for advancing week: %s", session.Status))
+	currentData, err := s.gmService.GetWeekData(sessionID, currentWeek)
+	if err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to get GM week data", err)
 	}
+	currentData = currentData.OnDay(session.Day)
 
 	nextWeek := currentWeek + 1
+	nextStatus := game_session.WeekStatus(nextWeek)
 	gmData, err := s.gmService.GetWeekData(sessionID, nextWeek)
 	if err != nil {
 		return errors.Wrap(errors.ErrInternal, "failed to get GM week data", err)
 	}
 
-	holdingsValue := 0.0
-	for ticker, holding := range session.Metadata.Holdings {
-		for _, stock := range gmData.Stocks {
-			if stock.Ticker == ticker {
-				holdingsValue += float64(holding.Quantity) * stock.Price
-				break
-			}
-		}
+	ensureMetadata(session)
+	snapshot := newSnapshot(session, currentWeek, currentData)
+	if err := s.revealHeadlines(session, currentWeek); err != nil {
+		return err
+	}
+	applyCorporateActions(session, nextWeek, gmData)
+
+	trades := executeProtections(session, nextWeek, gmData, s.fees)
+	trades = append(trades, executeBuyIns(session, nextWeek, gmData, s.fees)...)
+	trades = append(trades, executeLimitOrders(session, nextWeek, 1, gmData, s.fees)...)
+	for _, trade := range trades {
+		trade.Day = 1
 	}
 
 	session.Status = nextStatus
-	session.HoldingsValue = holdingsValue
-	session.TotalBalance = session.Cash + session.HoldingsValue
-	session.UpdatedAt = time.Now().Format(time.RFC3339)
+	session.Day = 1
+	updateValuation(session, gmData)
+
+	// The fills and snapshot are recorded before the session is updated because
+	// Update also writes the holdings to Redis, which the rollback cannot undo.
+	for _, trade := range trades {
+		if err := tx.RecordTrade(trade); err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to record trade", err)
+		}
+	}
+
+	if err := tx.RecordSnapshot(snapshot); err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to record snapshot", err)
+	}
 
 	if err := tx.Update(session); err != nil {
 		return errors.Wrap(errors.ErrInternal, "failed to update session", err)
@@ -426,10 +660,23 @@ func (s *service) AdvanceWeek(sessionID string) error {
 		return errors.Wrap(errors.ErrInternal, "failed to commit transaction", err)
 	}
 
+	s.publishFills(sessionID, nextWeek, 1, trades)
+	s.publish(game_session.Event{
+		Type:      game_session.EventWeekAdvanced,
+		SessionID: sessionID,
+		Week:      nextWeek,
+		Day:       1,
+	})
 	return nil
 }
 
-func (s *service) EndSession(sessionID string) (*game_session.GameSession, error) {
+func (s *service) EndSession(sessionID string) (*game_session.GameResult, error) {
+	return s.endSession(sessionID, false)
+}
+
+// endSession liquidates and scores the session. Sessions playing in a room
+// only end along with their room.
+func (s *service) endSession(sessionID string, byRoom bool) (*game_session.GameResult, error) {
 	tx, err := s.repo.BeginTransaction(sessionID)
 	if err != nil {
 		return nil, errors.Wrap(errors.ErrInternal, "failed to begin transaction", err)
@@ -437,39 +684,116 @@ func (s *service) EndSession(sessionID string) (*game_session.GameSession, error
 	defer tx.Rollback()
 
 	session := tx.GetSession()
+	if session.Mode == game_session.ModeRoom && !byRoom {
+		return nil, errors.New(errors.ErrConflict, "the session ends along with its room")
+	}
 
 	currentWeek, err := getCurrentWeek(session.Status)
 	if err != nil {
 		return nil, errors.Wrap(errors.ErrInvalidInput, "failed to get current week", err)
 	}
 
-	if currentWeek != 5 {
-		return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("can only end session in week 5, current week: %d", currentWeek))
+	if currentWeek != session.Rules.Weeks {
+		return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("can only end session in week %d, current week: %d", session.Rules.Weeks, currentWeek))
 	}
 
-	gmData, err := s.gmService.GetWeekData(sessionID, currentWeek)
+	weeks := make([]*gm_session.GMWeekData, session.Rules.Weeks)
+	for i := range weeks {
+		weeks[i], err = s.gmService.GetWeekData(sessionID, i+1)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrInternal, "failed to get GM week data", err)
+		}
+	}
+	gmData := weeks[currentWeek-1].OnDay(session.Day)
+
+	history, err := s.repo.FindSnapshotsBySessionID(sessionID)
 	if err != nil {
-		return nil, errors.Wrap(errors.ErrInternal, "failed to get GM week data", err)
+		return nil, errors.Wrap(errors.ErrInternal, "failed to get session history", err)
 	}
 
+	// Long positions are sold and short positions force-covered at the final week's prices.
+	var trades []*game_session.Trade
 	for ticker, holding := range session.Metadata.Holdings {
-		var stockPrice float64
-		for _, stock := range gmData.Stocks {
-			if stock.Ticker == ticker {
-				stockPrice = stock.Price
-				break
-			}
+		if holding.Quantity == 0 {
+			continue
+		}
+
+		stockPrice, found := findStockPrice(gmData, ticker)
+		if !found {
+			return nil, errors.New(errors.ErrNotFound, fmt.Sprintf("stock %s not found in final week data", ticker))
+		}
+		side, quantity := game_session.OrderSideSell, holding.Quantity
+		if holding.IsShort() {
+			side, quantity = game_session.OrderSideBuy, -holding.Quantity
 		}
 
-		saleProceeds := stockPrice * float64(holding.Quantity)
-		session.Cash += saleProceeds
+		fee := s.fees.Fee(stockPrice.Mul(quantity))
+		cashBefore := session.Cash
+		session.Cash += holding.Value(stockPrice) - fee
+		session.FeesPaid += fee
+
+		trade := newTrade(session, ticker, side, quantity, stockPrice, fee, cashBefore)
+		trade.Week = currentWeek
+		trade.Day = session.Day
+		trade.Source = game_session.TradeSourceLiquidation
+		trades = append(trades, trade)
 	}
 
+	expireOpenOrders(session)
 	session.Metadata.Holdings = make(map[string]game_session.HoldingInfo)
-	session.HoldingsValue = 0
+	session.HoldingsValue = money.Zero
 	session.TotalBalance = session.Cash
+	steps := tradingSteps(weeks, session.Day)
+	scoreAgainstBenchmark(session, benchmarkReturn(steps))
+	optimalBalance, optimalTrades := solveOptimal(session.Rules.StartingCash, steps)
+	session.Efficiency = math.Min(session.TotalBalance.Ratio(optimalBalance), 1)
+
+	if err := s.revealHeadlines(session, currentWeek); err != nil {
+		return nil, err
+	}
+	session.DetectionScore = detectionScore(session.Metadata.HeadlineReveals)
+
+	balances := []money.Money{session.Rules.StartingCash}
+	for _, snapshot := range history {
+		if snapshot.Week < currentWeek {
+			balances = append(balances, snapshot.TotalBalance)
+		}
+	}
+	scoreRisk(session, append(balances, session.TotalBalance))
 	session.Status = game_session.StatusFinished
 	session.UpdatedAt = time.Now().Format(time.RFC3339)
+	session.FinishedAt = session.UpdatedAt
+
+	resultToken, err := generateSecureToken()
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to generate result token", err)
+	}
+	session.ResultToken = resultToken
+
+	for _, trade := range trades {
+		if err := tx.RecordTrade(trade); err != nil {
+			return nil, errors.Wrap(errors.ErrInternal, "failed to record trade", err)
+		}
+	}
+
+	if err := tx.RecordSnapshot(newSnapshot(session, currentWeek, gmData)); err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to record snapshot", err)
+	}
+
+	result := &game_session.GameResult{
+		GameSession:     *session,
+		OptimalBalance:  optimalBalance,
+		OptimalTrades:   optimalTrades,
+		OptimalStrategy: game_session.OptimalStrategyLongOnly,
+	}
+
+	archive, err := newArchive(result, weeks)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to encode archive", err)
+	}
+	if err := tx.Archive(archive); err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to archive session", err)
+	}
 
 	if err := tx.Update(session); err != nil {
 		return nil, errors.Wrap(errors.ErrInternal, "failed to update session", err)
@@ -479,13 +803,51 @@ func (s *service) EndSession(sessionID string) (*game_session.GameSession, error
 		return nil, errors.Wrap(errors.ErrInternal, "failed to commit transaction", err)
 	}
 
-	return session, nil
+	s.publish(game_session.Event{
+		Type:      game_session.EventSessionFinished,
+		SessionID: sessionID,
+		Week:      currentWeek,
+		Day:       session.Day,
+	})
+	return result, nil
+}
+
+func (s *service) GetTrades(sessionID string) ([]game_session.Trade, error) {
+	return s.repo.FindTradesBySessionID(sessionID)
 }
 
-func (s *service) SaveGMWeekData(sessionID string, gmData map[string]*gm_session.GMWeekData) error {
-	return s.gmService.SaveGMWeekData(sessionID, gmData)
+func (s *service) SaveGMWeekData(sessionID string, gmData map[string]*gm_session.GMWeekData, rules game_session.GameRules) error {
+	return s.gmService.SaveGMWeekData(sessionID, gmData, rules)
 }
 
+// GetWeekData returns the week's data as far as the player has seen it: weeks
+// not started yet are refused and the current week's daily prices stop at the
+// session's trading day.
 func (s *service) GetWeekData(sessionID string, week int) (*gm_session.GMWeekData, error) {
-	return s.gmService.GetWeekData(sessionID, week)
+	session, err := s.repo.FindBySessionID(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentWeek, err := getCurrentWeek(session.Status)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInvalidInput, "failed to get current week", err)
+	}
+	if week > currentWeek {
+		return nil, errors.New(errors.ErrForbidden, fmt.Sprintf("week %d has not started yet", week))
+	}
+
+	weekData, err := s.gmService.GetWeekData(sessionID, week)
+	if err != nil {
+		return nil, err
+	}
+	if week == currentWeek {
+		for i := range weekData.Stocks {
+			stock := &weekData.Stocks[i]
+			if len(stock.DailyPrices) > session.Day {
+				stock.DailyPrices = stock.DailyPrices[:session.Day]
+			}
+		}
+	}
+	return weekData, nil
 }
@@ -0,0 +1,116 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/domain/gm_session"
+	"backend/pkg/errors"
+	"backend/pkg/money"
+	"fmt"
+)
+
+const (
+	// shortMarginRate is the extra cash, as a fraction of the sale proceeds,
+	// reserved on top of the proceeds themselves when opening a short.
+	shortMarginRate = 0.5
+	// shortMaintenanceRatio triggers a forced buy-in once the collateral no
+	// longer covers the cost of buying the shares back by this ratio.
+	shortMaintenanceRatio = 1.25
+)
+
+func openShort(session *game_session.GameSession, ticker string, quantity int, price money.Money, fee money.Money) error {
+	proceeds := price.Mul(quantity)
+	margin := proceeds.MulRate(shortMarginRate)
+
+	if margin+fee > session.Cash {
+		return errors.New(errors.ErrInvalidInput, fmt.Sprintf("insufficient funds for short collateral: need %s, have %s", margin+fee, session.Cash))
+	}
+
+	holding := session.Metadata.Holdings[ticker]
+	if holding.Quantity == 0 {
+		holding = game_session.HoldingInfo{RealizedPnL: holding.RealizedPnL}
+	}
+	holding.Quantity -= quantity
+	holding.TotalSpent += proceeds - fee
+	holding.Collateral += proceeds + margin
+	session.Metadata.Holdings[ticker] = holding
+
+	session.Cash -= margin + fee
+	session.FeesPaid += fee
+	return nil
+}
+
+func coverShort(session *game_session.GameSession, ticker string, quantity int, price money.Money, fee money.Money) error {
+	holding := session.Metadata.Holdings[ticker]
+	shortQuantity := -holding.Quantity
+
+	if quantity > shortQuantity {
+		return errors.New(errors.ErrInvalidInput, fmt.Sprintf("cannot buy %d shares while short %d: cover the short position first", quantity, shortQuantity))
+	}
+
+	cost := price.Mul(quantity) + fee
+	released := holding.Collateral.MulDiv(int64(quantity), int64(shortQuantity))
+
+	if cost-released > session.Cash {
+		return errors.New(errors.ErrInvalidInput, fmt.Sprintf("insufficient funds: need %s, have %s", cost-released, session.Cash))
+	}
+
+	proceeds := holding.TotalSpent.MulDiv(int64(quantity), int64(shortQuantity))
+	holding.TotalSpent -= proceeds
+	holding.RealizedPnL += proceeds - cost
+	holding.Collateral -= released
+	holding.Quantity += quantity
+	if holding.Quantity == 0 {
+		holding = game_session.HoldingInfo{RealizedPnL: holding.RealizedPnL}
+	}
+	session.Metadata.Holdings[ticker] = holding
+
+	session.Cash += released - cost
+	session.FeesPaid += fee
+	return nil
+}
+
+// executeBuyIns force-covers the short positions whose collateral no longer
+// covers the cost of buying the shares back at the given week's price. Any
+// shortfall beyond the collateral is taken from cash.
+func executeBuyIns(session *game_session.GameSession, week int, gmData *gm_session.GMWeekData, fees FeeModel) []*game_session.Trade {
+	var trades []*game_session.Trade
+	for ticker, holding := range session.Metadata.Holdings {
+		if !holding.IsShort() {
+			continue
+		}
+
+		price, found := findStockPrice(gmData, ticker)
+		if !found {
+			continue
+		}
+
+		quantity := -holding.Quantity
+		liability := price.Mul(quantity)
+		if liability.MulRate(shortMaintenanceRatio) < holding.Collateral {
+			continue
+		}
+
+		fee := fees.Fee(liability)
+		cashBefore := session.Cash
+		session.Cash += holding.Collateral - liability - fee
+		session.FeesPaid += fee
+		session.Metadata.Holdings[ticker] = game_session.HoldingInfo{
+			RealizedPnL: holding.RealizedPnL + holding.TotalSpent - liability - fee,
+		}
+
+		trade := newTrade(session, ticker, game_session.OrderSideBuy, quantity, price, fee, cashBefore)
+		trade.Week = week
+		trade.Source = game_session.TradeSourceFor(game_session.ReasonBuyIn)
+		trades = append(trades, trade)
+
+		session.Metadata.ProtectionFills = append(session.Metadata.ProtectionFills, game_session.ProtectionFill{
+			Ticker:   ticker,
+			Week:     week,
+			Quantity: quantity,
+			Price:    price,
+			Reason:   game_session.ReasonBuyIn,
+		})
+	}
+
+	return trades
+}
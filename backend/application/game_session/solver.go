@@ -0,0 +1,205 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/domain/gm_session"
+	"backend/pkg/money"
+	"sort"
+)
+
+// maxSolverNodes bounds the branch-and-bound search of a single week. Past it
+// the best allocation found so far is used.
+const maxSolverNodes = 200000
+
+// tradingStep is one trading day of the game, priced at that day.
+type tradingStep struct {
+	week   int
+	day    int
+	gmData *gm_session.GMWeekData
+}
+
+// tradingSteps lists every trading day played, from the first day of the first
+// week to the given day of the last. A week's corporate actions only apply on
+// its first day.
+func tradingSteps(weeks []*gm_session.GMWeekData, lastDay int) []tradingStep {
+	var steps []tradingStep
+	for w, weekData := range weeks {
+		days := game_session.TradingDaysPerWeek
+		if w == len(weeks)-1 {
+			days = lastDay
+		}
+		for day := 1; day <= days; day++ {
+			gmData := weekData.OnDay(day)
+			if day > 1 {
+				gmData.Events = nil
+			}
+			steps = append(steps, tradingStep{week: w + 1, day: day, gmData: gmData})
+		}
+	}
+	return steps
+}
+
+// solveOptimal finds the highest ending balance reachable from the starting
+// cash by trading whole shares at the daily prices, and the trades that reach
+// it. It considers long positions only and ignores fees.
+//
+// Without fees, holding a position into the next day is the same as selling
+// it and buying it back, so every day reduces to spending the whole balance
+// on the shares that gain the most by the next day, dividends and splits
+// included. Since a larger balance is never worse later on, maximizing each
+// day's balance maximizes the final one.
+func solveOptimal(startingCash money.Money, steps []tradingStep) (money.Money, []game_session.OptimalTrade) {
+	balance := startingCash
+	held := map[string]int{}
+	trades := []game_session.OptimalTrade{}
+
+	for i, step := range steps {
+		target := map[string]int{}
+		if i+1 < len(steps) {
+			target = bestAllocation(balance, step.gmData, steps[i+1].gmData)
+		}
+
+		trades = append(trades, rebalanceTrades(step, held, target)...)
+
+		if i+1 < len(steps) {
+			next := steps[i+1].gmData
+			balance += carriedValue(target, next) - positionsValue(target, step.gmData)
+			target = splitPositions(target, next)
+		}
+		held = target
+	}
+
+	return balance, trades
+}
+
+func positionsValue(positions map[string]int, gmData *gm_session.GMWeekData) money.Money {
+	value := money.Zero
+	for ticker, quantity := range positions {
+		price, _ := findStockPrice(gmData, ticker)
+		value += price.Mul(quantity)
+	}
+	return value
+}
+
+// carriedValue is what the positions held into the given week are worth there,
+// dividends and splits included.
+func carriedValue(positions map[string]int, gmData *gm_session.GMWeekData) money.Money {
+	value := money.Zero
+	for ticker, quantity := range positions {
+		value += shareValue(gmData, ticker).Mul(quantity)
+	}
+	return value
+}
+
+// rebalanceTrades lists the sells then buys that turn the held positions into
+// the target ones at the given day's prices.
+func rebalanceTrades(step tradingStep, held map[string]int, target map[string]int) []game_session.OptimalTrade {
+	tickers := make([]string, 0, len(held)+len(target))
+	seen := map[string]struct{}{}
+	for _, positions := range []map[string]int{held, target} {
+		for ticker := range positions {
+			if _, ok := seen[ticker]; !ok {
+				seen[ticker] = struct{}{}
+				tickers = append(tickers, ticker)
+			}
+		}
+	}
+	sort.Strings(tickers)
+
+	var trades []game_session.OptimalTrade
+	for _, side := range []game_session.OrderSide{game_session.OrderSideSell, game_session.OrderSideBuy} {
+		for _, ticker := range tickers {
+			delta := target[ticker] - held[ticker]
+			if delta == 0 || (delta < 0) != (side == game_session.OrderSideSell) {
+				continue
+			}
+			price, _ := findStockPrice(step.gmData, ticker)
+			trades = append(trades, game_session.OptimalTrade{
+				Week:     step.week,
+				Day:      step.day,
+				Ticker:   ticker,
+				Side:     side,
+				Quantity: abs(delta),
+				Price:    price,
+			})
+		}
+	}
+	return trades
+}
+
+type knapsackItem struct {
+	ticker string
+	price  int64
+	gain   int64
+}
+
+// bestAllocation picks the whole-share quantities, bought at this week's
+// prices within the budget, that gain the most by the next week. It is an
+// unbounded knapsack solved by depth-first branch and bound over the stocks
+// sorted by gain per dollar, bounded by the linear relaxation.
+func bestAllocation(budget money.Money, now *gm_session.GMWeekData, next *gm_session.GMWeekData) map[string]int {
+	var items []knapsackItem
+	for _, stock := range now.Stocks {
+		if _, found := findStockPrice(next, stock.Ticker); !found || stock.Price <= 0 {
+			continue
+		}
+		nextValue := shareValue(next, stock.Ticker)
+		if nextValue <= stock.Price {
+			continue
+		}
+		items = append(items, knapsackItem{
+			ticker: stock.Ticker,
+			price:  stock.Price.Cents(),
+			gain:   (nextValue - stock.Price).Cents(),
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].gain*items[j].price > items[j].gain*items[i].price
+	})
+
+	n := len(items)
+	best := make([]int64, n)
+	current := make([]int64, n)
+	bestGain := int64(-1)
+	nodes := 0
+
+	var search func(i int, remaining int64, gain int64)
+	search = func(i int, remaining int64, gain int64) {
+		nodes++
+		if i == n {
+			if gain > bestGain {
+				bestGain = gain
+				copy(best, current)
+			}
+			return
+		}
+
+		item := items[i]
+		for q := remaining / item.price; q >= 0; q-- {
+			left := remaining - q*item.price
+			total := gain + q*item.gain
+
+			// The bound only shrinks as q decreases, so once it fails no
+			// smaller quantity of this stock can do better.
+			if i+1 < n && total+left*items[i+1].gain/items[i+1].price <= bestGain {
+				break
+			}
+
+			current[i] = q
+			search(i+1, left, total)
+			if i+1 == n || nodes > maxSolverNodes {
+				break
+			}
+		}
+		current[i] = 0
+	}
+	search(0, budget.Cents(), 0)
+
+	allocation := make(map[string]int)
+	for i, quantity := range best {
+		if quantity > 0 {
+			allocation[items[i].ticker] = int(quantity)
+		}
+	}
+	return allocation
+}
@@ -0,0 +1,241 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/domain/gm_session"
+	"backend/pkg/money"
+	"testing"
+)
+
+func stockAt(ticker string, price money.Money) gm_session.StockWeekInsight {
+	return gm_session.StockWeekInsight{Ticker: ticker, Price: price}
+}
+
+func stocksAt(stocks ...gm_session.StockWeekInsight) *gm_session.GMWeekData {
+	return &gm_session.GMWeekData{Stocks: stocks}
+}
+
+// bruteForceAllocation tries every affordable whole-share allocation and
+// returns the most the budget can be worth by the next week, cash left over
+// included.
+func bruteForceAllocation(budget money.Money, now *gm_session.GMWeekData, next *gm_session.GMWeekData) money.Money {
+	best := money.Zero
+	var try func(i int, cash money.Money, value money.Money)
+	try = func(i int, cash money.Money, value money.Money) {
+		if i == len(now.Stocks) {
+			if cash+value > best {
+				best = cash + value
+			}
+			return
+		}
+		stock := now.Stocks[i]
+		for q := 0; stock.Price.Mul(q) <= cash; q++ {
+			try(i+1, cash-stock.Price.Mul(q), value+shareValue(next, stock.Ticker).Mul(q))
+		}
+	}
+	try(0, budget, money.Zero)
+	return best
+}
+
+// bruteForceGame tries every sequence of whole-share long positions over the
+// steps, paying the fee on each trade and selling everything on the last step,
+// and returns the highest ending balance. The steps must have no corporate
+// actions.
+func bruteForceGame(cash money.Money, held map[string]int, steps []tradingStep, fees FeeModel) money.Money {
+	step := steps[0]
+	funds := cash + positionsValue(held, step.gmData)
+	best := money.FromCents(-1)
+
+	target := map[string]int{}
+	var try func(i int)
+	try = func(i int) {
+		if i == len(step.gmData.Stocks) {
+			after := cash
+			for _, stock := range step.gmData.Stocks {
+				delta := target[stock.Ticker] - held[stock.Ticker]
+				if delta != 0 {
+					after -= stock.Price.Mul(delta) + fees.Fee(stock.Price.Mul(abs(delta)))
+				}
+			}
+			if after.IsNegative() {
+				return
+			}
+			balance := after
+			if len(steps) > 1 {
+				positions := make(map[string]int, len(target))
+				for ticker, quantity := range target {
+					positions[ticker] = quantity
+				}
+				balance = bruteForceGame(after, positions, steps[1:], fees)
+			}
+			if balance > best {
+				best = balance
+			}
+			return
+		}
+
+		stock := step.gmData.Stocks[i]
+		for q := 0; stock.Price.Mul(q) <= funds; q++ {
+			target[stock.Ticker] = q
+			try(i + 1)
+			if len(steps) == 1 {
+				break
+			}
+		}
+		delete(target, stock.Ticker)
+	}
+	try(0)
+	return best
+}
+
+// TestBestAllocationMatchesBruteForce checks the branch and bound finds the
+// same best allocation as trying them all.
+func TestBestAllocationMatchesBruteForce(t *testing.T) {
+	tests := []struct {
+		name   string
+		budget money.Money
+		now    *gm_session.GMWeekData
+		next   *gm_session.GMWeekData
+	}{
+		{
+			name:   "cash left unspent",
+			budget: 1000,
+			now:    stocksAt(stockAt("AAPL", 300), stockAt("MSFT", 700)),
+			next:   stocksAt(stockAt("AAPL", 390), stockAt("MSFT", 840)),
+		},
+		{
+			name:   "best gain per dollar does not fill the budget",
+			budget: 1000,
+			now:    stocksAt(stockAt("AAPL", 600), stockAt("MSFT", 500)),
+			next:   stocksAt(stockAt("AAPL", 780), stockAt("MSFT", 640)),
+		},
+		{
+			name:   "single stock",
+			budget: 1050,
+			now:    stocksAt(stockAt("AAPL", 200)),
+			next:   stocksAt(stockAt("AAPL", 230)),
+		},
+		{
+			name:   "single stock falling",
+			budget: 1050,
+			now:    stocksAt(stockAt("AAPL", 200)),
+			next:   stocksAt(stockAt("AAPL", 150)),
+		},
+		{
+			name:   "three stocks",
+			budget: 2000,
+			now:    stocksAt(stockAt("AAPL", 370), stockAt("MSFT", 530), stockAt("NVDA", 910)),
+			next:   stocksAt(stockAt("AAPL", 420), stockAt("MSFT", 610), stockAt("NVDA", 1050)),
+		},
+		{
+			name:   "stock missing next week",
+			budget: 1000,
+			now:    stocksAt(stockAt("AAPL", 300), stockAt("MSFT", 250)),
+			next:   stocksAt(stockAt("MSFT", 260)),
+		},
+		{
+			name:   "split and dividend",
+			budget: 1500,
+			now:    stocksAt(stockAt("AAPL", 400), stockAt("MSFT", 300)),
+			next: &gm_session.GMWeekData{
+				Stocks: []gm_session.StockWeekInsight{stockAt("AAPL", 220), stockAt("MSFT", 290)},
+				Events: []gm_session.CorporateAction{
+					{Ticker: "AAPL", Type: game_session.ActionSplit, Ratio: 2},
+					{Ticker: "MSFT", Type: game_session.ActionDividend, Amount: 20},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		allocation := bestAllocation(tt.budget, tt.now, tt.next)
+
+		cost := positionsValue(allocation, tt.now)
+		if cost > tt.budget {
+			t.Errorf("%s: allocation %v costs %s, over the %s budget", tt.name, allocation, cost, tt.budget)
+			continue
+		}
+		got := tt.budget - cost + carriedValue(allocation, tt.next)
+		if want := bruteForceAllocation(tt.budget, tt.now, tt.next); got != want {
+			t.Errorf("%s: allocation %v is worth %s, brute force reaches %s", tt.name, allocation, got, want)
+		}
+	}
+}
+
+// TestSolveOptimalMatchesBruteForce checks the solver reaches the best balance
+// of every trading sequence without fees and that no sequence beats it once
+// fees are charged, as the results are scored against it.
+func TestSolveOptimalMatchesBruteForce(t *testing.T) {
+	days := func(prices ...[]gm_session.StockWeekInsight) []tradingStep {
+		steps := make([]tradingStep, len(prices))
+		for i, stocks := range prices {
+			steps[i] = tradingStep{week: 1, day: i + 1, gmData: stocksAt(stocks...)}
+		}
+		return steps
+	}
+
+	tests := []struct {
+		name  string
+		cash  money.Money
+		steps []tradingStep
+	}{
+		{
+			name: "two stocks",
+			cash: 1000,
+			steps: days(
+				[]gm_session.StockWeekInsight{stockAt("AAPL", 300), stockAt("MSFT", 450)},
+				[]gm_session.StockWeekInsight{stockAt("AAPL", 330), stockAt("MSFT", 420)},
+				[]gm_session.StockWeekInsight{stockAt("AAPL", 310), stockAt("MSFT", 470)},
+				[]gm_session.StockWeekInsight{stockAt("AAPL", 360), stockAt("MSFT", 480)},
+			),
+		},
+		{
+			name: "single stock",
+			cash: 1000,
+			steps: days(
+				[]gm_session.StockWeekInsight{stockAt("AAPL", 300)},
+				[]gm_session.StockWeekInsight{stockAt("AAPL", 270)},
+				[]gm_session.StockWeekInsight{stockAt("AAPL", 320)},
+				[]gm_session.StockWeekInsight{stockAt("AAPL", 350)},
+			),
+		},
+		{
+			name: "falling market",
+			cash: 1000,
+			steps: days(
+				[]gm_session.StockWeekInsight{stockAt("AAPL", 300), stockAt("MSFT", 450)},
+				[]gm_session.StockWeekInsight{stockAt("AAPL", 280), stockAt("MSFT", 400)},
+				[]gm_session.StockWeekInsight{stockAt("AAPL", 250), stockAt("MSFT", 390)},
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		balance, trades := solveOptimal(tt.cash, tt.steps)
+
+		if want := bruteForceGame(tt.cash, map[string]int{}, tt.steps, NoFee{}); balance != want {
+			t.Errorf("%s: solver reaches %s, brute force reaches %s", tt.name, balance, want)
+		}
+
+		cash := tt.cash
+		for _, trade := range trades {
+			if trade.Side == game_session.OrderSideBuy {
+				cash -= trade.Price.Mul(trade.Quantity)
+			} else {
+				cash += trade.Price.Mul(trade.Quantity)
+			}
+			if cash.IsNegative() {
+				t.Errorf("%s: cash goes negative buying %d %s on day %d", tt.name, trade.Quantity, trade.Ticker, trade.Day)
+			}
+		}
+		if cash != balance {
+			t.Errorf("%s: trades end with %s cash, solver balance %s", tt.name, cash, balance)
+		}
+
+		for _, fees := range []FeeModel{FlatFee{Amount: 10}, PercentageFee{Rate: 0.01}} {
+			if withFees := bruteForceGame(tt.cash, map[string]int{}, tt.steps, fees); withFees > balance {
+				t.Errorf("%s: brute force with %T reaches %s, above the solver's %s", tt.name, fees, withFees, balance)
+			}
+		}
+	}
+}
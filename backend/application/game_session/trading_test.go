@@ -0,0 +1,194 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/domain/gm_session"
+	"backend/pkg/money"
+	"testing"
+)
+
+func newTestSession(cash money.Money) *game_session.GameSession {
+	return &game_session.GameSession{
+		SessionID:    "test",
+		Cash:         cash,
+		TotalBalance: cash,
+		Metadata: &game_session.SessionMetadata{
+			Holdings: make(map[string]game_session.HoldingInfo),
+		},
+	}
+}
+
+func weekAt(ticker string, price money.Money) *gm_session.GMWeekData {
+	return &gm_session.GMWeekData{
+		Stocks: []gm_session.StockWeekInsight{{Ticker: ticker, Price: price}},
+	}
+}
+
+// TestTradeSequenceReconciles runs long, short and covering trades with fees
+// and checks the books balance after each one: trading at the market price
+// only costs the fee, the ledger's cash chain is unbroken, and once every
+// position is closed the cash is the starting cash plus the realized P&L.
+func TestTradeSequenceReconciles(t *testing.T) {
+	fees, err := NewFeeModel("percentage", 0.001, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		name     string
+		side     game_session.OrderSide
+		ticker   string
+		quantity int
+		price    money.Money
+	}{
+		{name: "open long", side: game_session.OrderSideBuy, ticker: "AAPL", quantity: 10, price: 15000},
+		{name: "add to long", side: game_session.OrderSideBuy, ticker: "AAPL", quantity: 5, price: 15237},
+		{name: "partial sell", side: game_session.OrderSideSell, ticker: "AAPL", quantity: 7, price: 16011},
+		{name: "close long", side: game_session.OrderSideSell, ticker: "AAPL", quantity: 8, price: 14999},
+		{name: "open short", side: game_session.OrderSideSell, ticker: "TSLA", quantity: 4, price: 25000},
+		{name: "add to short", side: game_session.OrderSideSell, ticker: "TSLA", quantity: 3, price: 24055},
+		{name: "partial cover", side: game_session.OrderSideBuy, ticker: "TSLA", quantity: 5, price: 26001},
+		{name: "close short", side: game_session.OrderSideBuy, ticker: "TSLA", quantity: 2, price: 23000},
+	}
+
+	startingCash := money.FromCents(1000000)
+	session := newTestSession(startingCash)
+	var trades []*game_session.Trade
+
+	for _, step := range steps {
+		gmData := weekAt(step.ticker, step.price)
+		updateValuation(session, gmData)
+		balanceBefore := session.TotalBalance
+		cashBefore := session.Cash
+
+		var trade *game_session.Trade
+		if step.side == game_session.OrderSideBuy {
+			trade, err = buyShares(session, step.ticker, step.quantity, step.price, fees)
+		} else {
+			trade, err = sellShares(session, step.ticker, step.quantity, step.price, fees)
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		updateValuation(session, gmData)
+
+		if got := session.Cash + session.HoldingsValue; got != session.TotalBalance {
+			t.Errorf("%s: cash %s + holdings %s = %s, total balance %s", step.name, session.Cash, session.HoldingsValue, got, session.TotalBalance)
+		}
+		if want := balanceBefore - trade.Fee; session.TotalBalance != want {
+			t.Errorf("%s: total balance %s, want %s before the trade less the %s fee", step.name, session.TotalBalance, want, trade.Fee)
+		}
+		if trade.CashBefore != cashBefore || trade.CashAfter != session.Cash {
+			t.Errorf("%s: trade cash %s -> %s, session cash %s -> %s", step.name, trade.CashBefore, trade.CashAfter, cashBefore, session.Cash)
+		}
+		if want := fees.Fee(step.price.Mul(step.quantity)); trade.Fee != want {
+			t.Errorf("%s: fee %s, want %s", step.name, trade.Fee, want)
+		}
+		trades = append(trades, trade)
+	}
+
+	feesPaid := money.Zero
+	for i, trade := range trades {
+		feesPaid += trade.Fee
+		if i > 0 && trade.CashBefore != trades[i-1].CashAfter {
+			t.Errorf("trade %d starts with %s cash, trade %d ended with %s", i, trade.CashBefore, i-1, trades[i-1].CashAfter)
+		}
+	}
+	if trades[0].CashBefore != startingCash {
+		t.Errorf("first trade starts with %s cash, want %s", trades[0].CashBefore, startingCash)
+	}
+	if session.FeesPaid != feesPaid {
+		t.Errorf("fees paid %s, want the %s charged on the trades", session.FeesPaid, feesPaid)
+	}
+
+	realized := money.Zero
+	for ticker, holding := range session.Metadata.Holdings {
+		if holding.Quantity != 0 || holding.TotalSpent != 0 || holding.Collateral != 0 {
+			t.Errorf("%s still has quantity %d, basis %s and collateral %s", ticker, holding.Quantity, holding.TotalSpent, holding.Collateral)
+		}
+		realized += holding.RealizedPnL
+	}
+	if want := startingCash + realized; session.Cash != want {
+		t.Errorf("ending cash %s, want starting cash %s plus realized P&L %s", session.Cash, startingCash, realized)
+	}
+}
+
+// TestRejectedTradesLeaveSessionUntouched checks trades that cannot go
+// through change neither the cash nor the holdings.
+func TestRejectedTradesLeaveSessionUntouched(t *testing.T) {
+	fees := FlatFee{Amount: money.FromCents(100)}
+
+	tests := []struct {
+		name     string
+		setup    func(*game_session.GameSession)
+		side     game_session.OrderSide
+		quantity int
+		price    money.Money
+	}{
+		{
+			name:     "buy beyond cash",
+			side:     game_session.OrderSideBuy,
+			quantity: 100,
+			price:    10000,
+		},
+		{
+			name:     "buy whose fee exceeds cash",
+			side:     game_session.OrderSideBuy,
+			quantity: 10,
+			price:    10000,
+			setup: func(s *game_session.GameSession) {
+				s.Cash = money.FromCents(100050)
+			},
+		},
+		{
+			name:     "sell more than held",
+			side:     game_session.OrderSideSell,
+			quantity: 6,
+			price:    10000,
+			setup: func(s *game_session.GameSession) {
+				s.Metadata.Holdings["AAPL"] = game_session.HoldingInfo{Quantity: 5, TotalSpent: 50100}
+			},
+		},
+		{
+			name:     "cover more than short",
+			side:     game_session.OrderSideBuy,
+			quantity: 6,
+			price:    10000,
+			setup: func(s *game_session.GameSession) {
+				s.Metadata.Holdings["AAPL"] = game_session.HoldingInfo{Quantity: -5, TotalSpent: 49900, Collateral: 75000}
+			},
+		},
+		{
+			name:     "short without margin",
+			side:     game_session.OrderSideSell,
+			quantity: 100,
+			price:    10000,
+		},
+	}
+
+	for _, tt := range tests {
+		session := newTestSession(money.FromCents(500000))
+		if tt.setup != nil {
+			tt.setup(session)
+		}
+		cash := session.Cash
+		holding := session.Metadata.Holdings["AAPL"]
+
+		var err error
+		if tt.side == game_session.OrderSideBuy {
+			_, err = buyShares(session, "AAPL", tt.quantity, tt.price, fees)
+		} else {
+			_, err = sellShares(session, "AAPL", tt.quantity, tt.price, fees)
+		}
+		if err == nil {
+			t.Errorf("%s: want an error", tt.name)
+			continue
+		}
+		if session.Cash != cash || session.FeesPaid != 0 {
+			t.Errorf("%s: cash %s and fees %s, want %s and 0", tt.name, session.Cash, session.FeesPaid, cash)
+		}
+		if session.Metadata.Holdings["AAPL"] != holding {
+			t.Errorf("%s: holding changed to %+v", tt.name, session.Metadata.Holdings["AAPL"])
+		}
+	}
+}
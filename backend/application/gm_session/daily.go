@@ -0,0 +1,75 @@
+package gm_session
+
+import (
+	"backend/domain/game_session"
+	"backend/domain/gm_session"
+	"backend/pkg/money"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+)
+
+// dailyNoiseShare is the largest daily deviation from the interpolated price,
+// as a share of the largest weekly move the difficulty allows.
+const dailyNoiseShare = 0.25
+
+// fillDailyPrices derives every stock's daily prices from the GM's weekly
+// ones. Each week starts at the GM's price and drifts towards the next week's,
+// adjusted for any split in between, with noise seeded by the seed, week and
+// ticker so the same scenario always plays out the same way. The final week
+// has no target and wanders around its price. Stocks that already have their
+// daily prices keep them.
+func fillDailyPrices(seed string, gmData map[string]*gm_session.GMWeekData, rules game_session.GameRules) {
+	maxNoise := rules.Difficulty.Profile().MaxWeeklyMove * dailyNoiseShare
+
+	for week := 1; week <= rules.Weeks; week++ {
+		weekData := gmData[fmt.Sprintf("week%d", week)]
+		next := gmData[fmt.Sprintf("week%d", week+1)]
+
+		for i := range weekData.Stocks {
+			stock := &weekData.Stocks[i]
+			if len(stock.DailyPrices) == game_session.TradingDaysPerWeek {
+				continue
+			}
+
+			target := stock.Price
+			if week < rules.Weeks && next != nil {
+				if nextPrice, found := findPrice(next, stock.Ticker); found {
+					target = unsplitPrice(nextPrice, stock.Ticker, next)
+				}
+			}
+
+			random := rand.New(rand.NewSource(dailySeed(seed, week, stock.Ticker)))
+			stock.DailyPrices = make([]money.Money, game_session.TradingDaysPerWeek)
+			stock.DailyPrices[0] = stock.Price
+			for day := 1; day < game_session.TradingDaysPerWeek; day++ {
+				drift := stock.Price + (target-stock.Price).MulDiv(int64(day), game_session.TradingDaysPerWeek)
+				noise := (random.Float64()*2 - 1) * maxNoise
+				stock.DailyPrices[day] = max(drift+drift.MulRate(noise), money.FromCents(1))
+			}
+		}
+	}
+}
+
+// unsplitPrice restates a price after the week's splits in terms of the
+// shares before them.
+func unsplitPrice(price money.Money, ticker string, weekData *gm_session.GMWeekData) money.Money {
+	for _, event := range weekData.Events {
+		if event.Ticker != ticker {
+			continue
+		}
+		switch event.Type {
+		case game_session.ActionSplit:
+			price = price.Mul(event.Ratio)
+		case game_session.ActionReverseSplit:
+			price = price.MulDiv(1, int64(event.Ratio))
+		}
+	}
+	return price
+}
+
+func dailySeed(seed string, week int, ticker string) int64 {
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%s:%d:%s", seed, week, ticker)
+	return int64(hash.Sum64())
+}
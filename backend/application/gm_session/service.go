@@ -1,14 +1,26 @@
 package gm_session
 
 import (
+	"backend/domain/game_session"
 	"backend/domain/gm_session"
 	"backend/pkg/errors"
+	"backend/pkg/money"
 	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+const (
+	minSplitRatio = 2
+	maxSplitRatio = 10
 )
 
 type Service interface {
-	SaveGMWeekData(sessionID string, gmData map[string]*gm_session.GMWeekData) error
+	PrepareWeekData(seed string, gmData map[string]*gm_session.GMWeekData, rules game_session.GameRules) error
+	SaveGMWeekData(sessionID string, gmData map[string]*gm_session.GMWeekData, rules game_session.GameRules) error
 	GetWeekData(sessionID string, week int) (*gm_session.GMWeekData, error)
+	GetFakeHeadlines(sessionID string, week int) ([]int, error)
 }
 
 type service struct {
@@ -21,13 +33,57 @@ func NewService(repo gm_session.Repository) Service {
 	}
 }
 
-func (s *service) SaveGMWeekData(sessionID string, gmData map[string]*gm_session.GMWeekData) error {
-	for i := 1; i <= 5; i++ {
+// PrepareWeekData validates the GM output against the rules and derives the
+// daily prices of the stocks that have none yet, seeding their noise with the
+// given seed.
+func (s *service) PrepareWeekData(seed string, gmData map[string]*gm_session.GMWeekData, rules game_session.GameRules) error {
+	for i := 1; i <= rules.Weeks; i++ {
 		weekKey := fmt.Sprintf("week%d", i)
 		weekData, exists := gmData[weekKey]
-		if !exists {
+		if !exists || weekData == nil {
 			return errors.New(errors.ErrInvalidInput, "missing data for "+weekKey)
 		}
+		if len(weekData.Headlines) != rules.HeadlinesPerWeek {
+			return errors.New(errors.ErrInvalidInput, fmt.Sprintf("%s has %d headlines, expected %d", weekKey, len(weekData.Headlines), rules.HeadlinesPerWeek))
+		}
+		if len(weekData.Stocks) != rules.StockCount() {
+			return errors.New(errors.ErrInvalidInput, fmt.Sprintf("%s has %d stocks, expected %d", weekKey, len(weekData.Stocks), rules.StockCount()))
+		}
+		if err := validateHeadlines(weekKey, weekData, rules); err != nil {
+			return err
+		}
+		if err := validateEvents(weekKey, i, weekData); err != nil {
+			return err
+		}
+	}
+
+	if err := validatePriceMoves(gmData, rules); err != nil {
+		return err
+	}
+
+	fillDailyPrices(seed, gmData, rules)
+	return nil
+}
+
+func (s *service) SaveGMWeekData(sessionID string, gmData map[string]*gm_session.GMWeekData, rules game_session.GameRules) error {
+	if err := s.PrepareWeekData(sessionID, gmData, rules); err != nil {
+		return err
+	}
+
+	for i := 1; i <= rules.Weeks; i++ {
+		weekKey := fmt.Sprintf("week%d", i)
+		weekData := gmData[weekKey]
+
+		var fakes []int
+		for j := range weekData.Headlines {
+			if weekData.Headlines[j].Fake {
+				fakes = append(fakes, j)
+				weekData.Headlines[j].Fake = false
+			}
+		}
+		if err := s.repo.SaveFakeHeadlines(sessionID, i, fakes); err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to save fake headlines for "+weekKey, err)
+		}
 
 		if err := s.repo.SaveWeekData(sessionID, i, weekData); err != nil {
 			return errors.Wrap(errors.ErrInternal, "failed to save data for "+weekKey, err)
@@ -37,9 +93,204 @@ func (s *service) SaveGMWeekData(sessionID string, gmData map[string]*gm_session
 	return nil
 }
 
+// validateHeadlines checks every headline points at stocks in play and the
+// week marks as many fake headlines as the session's difficulty asks for.
+func validateHeadlines(weekKey string, weekData *gm_session.GMWeekData, rules game_session.GameRules) error {
+	tickers := make(map[string]struct{}, len(weekData.Stocks))
+	for _, stock := range weekData.Stocks {
+		tickers[stock.Ticker] = struct{}{}
+	}
+
+	fakes := 0
+	for i, headline := range weekData.Headlines {
+		if headline.Text == "" {
+			return errors.New(errors.ErrInvalidInput, fmt.Sprintf("%s headline %d has no text", weekKey, i))
+		}
+		if headline.Direction != "" && !headline.Direction.IsValid() {
+			return errors.New(errors.ErrInvalidInput, fmt.Sprintf("%s headline %d has an invalid direction: %s", weekKey, i, headline.Direction))
+		}
+		for _, ticker := range headline.Tickers {
+			if _, ok := tickers[ticker]; !ok {
+				return errors.New(errors.ErrInvalidInput, fmt.Sprintf("%s headline %d mentions %s, which is not in play", weekKey, i, ticker))
+			}
+		}
+		if headline.Fake {
+			fakes++
+		}
+	}
+
+	expected := rules.Difficulty.Profile().FakeHeadlines(rules.HeadlinesPerWeek)
+	if fakes != expected {
+		return errors.New(errors.ErrInvalidInput, fmt.Sprintf("%s marks %d fake headlines, expected %d", weekKey, fakes, expected))
+	}
+	return nil
+}
+
+// validateEvents checks the week's corporate actions are well formed. They take
+// effect as the week starts, so the first week cannot have any.
+func validateEvents(weekKey string, week int, weekData *gm_session.GMWeekData) error {
+	if week == 1 && len(weekData.Events) > 0 {
+		return errors.New(errors.ErrInvalidInput, "week1 cannot schedule corporate actions")
+	}
+
+	for _, event := range weekData.Events {
+		price, found := findPrice(weekData, event.Ticker)
+		if !found {
+			return errors.New(errors.ErrInvalidInput, fmt.Sprintf("%s schedules a %s for %s, which is not in play", weekKey, event.Type, event.Ticker))
+		}
+
+		switch event.Type {
+		case game_session.ActionDividend:
+			if event.Amount <= 0 || event.Amount >= price {
+				return errors.New(errors.ErrInvalidInput, fmt.Sprintf("%s has an invalid dividend of %s for %s", weekKey, event.Amount, event.Ticker))
+			}
+		case game_session.ActionSplit, game_session.ActionReverseSplit:
+			if event.Ratio < minSplitRatio || event.Ratio > maxSplitRatio {
+				return errors.New(errors.ErrInvalidInput, fmt.Sprintf("%s has an invalid %s ratio of %d for %s, must be between %d and %d", weekKey, event.Type, event.Ratio, event.Ticker, minSplitRatio, maxSplitRatio))
+			}
+		default:
+			return errors.New(errors.ErrInvalidInput, fmt.Sprintf("%s has an invalid corporate action type: %s", weekKey, event.Type))
+		}
+	}
+	return nil
+}
+
+func findPrice(weekData *gm_session.GMWeekData, ticker string) (money.Money, bool) {
+	for _, stock := range weekData.Stocks {
+		if stock.Ticker == ticker {
+			return stock.Price, true
+		}
+	}
+	return 0, false
+}
+
+// validatePriceMoves rejects scenarios whose weekly price moves or analyst
+// actions stray from the session's difficulty: no move may exceed the headline
+// limit, a move no headline accounts for may not exceed the weekly limit, and
+// no more analyst actions than the difficulty allows may point against the
+// following week's move.
+func validatePriceMoves(gmData map[string]*gm_session.GMWeekData, rules game_session.GameRules) error {
+	profile := rules.Difficulty.Profile()
+	moves := weeklyMoves(gmData, rules)
+
+	for i := 1; i <= rules.Weeks; i++ {
+		weekKey := fmt.Sprintf("week%d", i)
+		weekData := gmData[weekKey]
+		for _, stock := range weekData.Stocks {
+			if stock.Price <= 0 {
+				return errors.New(errors.ErrInvalidInput, fmt.Sprintf("%s has a non-positive price for %s", weekKey, stock.Ticker))
+			}
+
+			move := math.Abs(moves[i][stock.Ticker])
+			if move > profile.MaxHeadlineMove {
+				return errors.New(errors.ErrInvalidInput, fmt.Sprintf("%s moves %s by %.1f%%, more than the %.0f%% allowed on %s difficulty", weekKey, stock.Ticker, move*100, profile.MaxHeadlineMove*100, rules.Difficulty))
+			}
+			if move > profile.MaxWeeklyMove && !hasHeadline(weekData, stock) {
+				return errors.New(errors.ErrInvalidInput, fmt.Sprintf("%s moves %s by %.1f%% with no headline about it, more than the %.0f%% allowed on %s difficulty", weekKey, stock.Ticker, move*100, profile.MaxWeeklyMove*100, rules.Difficulty))
+			}
+		}
+
+		// The last week's actions have no following week to be judged by.
+		if i == rules.Weeks {
+			continue
+		}
+		directed, misleading := 0, 0
+		for _, stock := range weekData.Stocks {
+			direction := actionDirection(stock.Action)
+			if direction == 0 {
+				continue
+			}
+			directed++
+			if next := moves[i+1][stock.Ticker]; direction*next < 0 {
+				misleading++
+			}
+		}
+		allowed := int(math.Ceil(profile.MisleadingActionRatio * float64(directed)))
+		if misleading > allowed {
+			return errors.New(errors.ErrInvalidInput, fmt.Sprintf("%s has %d of %d analyst actions pointing against the next week's move, more than the %.0f%% allowed on %s difficulty", weekKey, misleading, directed, profile.MisleadingActionRatio*100, rules.Difficulty))
+		}
+	}
+
+	return nil
+}
+
+// weeklyMoves returns each stock's price move of every week, by week and
+// ticker. Moves are measured on split-adjusted prices, as a split changes the
+// price without changing what a holding is worth. The first week's move is the
+// GM's own estimate.
+func weeklyMoves(gmData map[string]*gm_session.GMWeekData, rules game_session.GameRules) map[int]map[string]float64 {
+	moves := make(map[int]map[string]float64, rules.Weeks)
+	previous := make(map[string]money.Money)
+
+	for i := 1; i <= rules.Weeks; i++ {
+		weekData := gmData[fmt.Sprintf("week%d", i)]
+		for _, event := range weekData.Events {
+			prevPrice, ok := previous[event.Ticker]
+			if !ok {
+				continue
+			}
+			switch event.Type {
+			case game_session.ActionSplit:
+				previous[event.Ticker] = prevPrice.MulDiv(1, int64(event.Ratio))
+			case game_session.ActionReverseSplit:
+				previous[event.Ticker] = prevPrice.Mul(event.Ratio)
+			}
+		}
+
+		moves[i] = make(map[string]float64, len(weekData.Stocks))
+		for _, stock := range weekData.Stocks {
+			move := stock.PriceChange
+			if prevPrice, ok := previous[stock.Ticker]; ok && prevPrice > 0 {
+				move = (stock.Price - prevPrice).Ratio(prevPrice)
+			}
+			moves[i][stock.Ticker] = move
+			previous[stock.Ticker] = stock.Price
+		}
+	}
+	return moves
+}
+
+// hasHeadline reports whether one of the week's headlines is about the stock,
+// naming it or its category. A headline naming neither is about the whole
+// market.
+func hasHeadline(weekData *gm_session.GMWeekData, stock gm_session.StockWeekInsight) bool {
+	for _, headline := range weekData.Headlines {
+		if len(headline.Tickers) == 0 && len(headline.Categories) == 0 {
+			return true
+		}
+		if slices.Contains(headline.Tickers, stock.Ticker) {
+			return true
+		}
+		if stock.Category != "" && slices.Contains(headline.Categories, stock.Category) {
+			return true
+		}
+	}
+	return false
+}
+
+// actionDirection is 1 for analyst actions that call for a rise, -1 for those
+// that call for a fall and 0 for those that call for neither.
+func actionDirection(action string) float64 {
+	switch strings.ToLower(strings.TrimSpace(action)) {
+	case "upgraded", "target raised":
+		return 1
+	case "downgraded", "target lowered":
+		return -1
+	default:
+		return 0
+	}
+}
+
 func (s *service) GetWeekData(sessionID string, week int) (*gm_session.GMWeekData, error) {
-	if week < 1 || week > 5 {
-		return nil, errors.New(errors.ErrInvalidInput, "invalid week number: must be between 1 and 5")
+	if week < 1 || week > game_session.MaxWeeks {
+		return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("invalid week number: must be between 1 and %d", game_session.MaxWeeks))
 	}
 	return s.repo.GetWeekData(sessionID, week)
 }
+
+func (s *service) GetFakeHeadlines(sessionID string, week int) ([]int, error) {
+	if week < 1 || week > game_session.MaxWeeks {
+		return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("invalid week number: must be between 1 and %d", game_session.MaxWeeks))
+	}
+	return s.repo.GetFakeHeadlines(sessionID, week)
+}
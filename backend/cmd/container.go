@@ -1,6 +1,9 @@
 package main
 
 import (
+	"os"
+	"strconv"
+
 	"gorm.io/gorm"
 
 	categoryApp "backend/application/category"
@@ -8,10 +11,13 @@ import (
 	gmSessionApp "backend/application/gm_session"
 	stockApp "backend/application/stock"
 	"backend/infrastructure/ai_model"
+	"backend/infrastructure/events"
 	"backend/infrastructure/redis"
 	categoryRepo "backend/infrastructure/repositories/category"
+	challengeRepo "backend/infrastructure/repositories/challenge"
 	gameSessionRepo "backend/infrastructure/repositories/game_session"
 	gmSessionRepo "backend/infrastructure/repositories/gm_session"
+	roomRepo "backend/infrastructure/repositories/room"
 	stockRepo "backend/infrastructure/repositories/stock"
 	"backend/infrastructure/taskrunner"
 )
@@ -29,6 +35,18 @@ func NewContainer(db *gorm.DB) *Container {
 	tr := taskrunner.New(100)
 	tr.Start()
 
+	if err := db.AutoMigrate(
+		&gameSessionRepo.GameSessionEntity{},
+		&gameSessionRepo.GameTradeEntity{},
+		&gameSessionRepo.GameSessionSnapshotEntity{},
+		&gameSessionRepo.GameSessionArchiveEntity{},
+		&challengeRepo.DailyChallengeEntity{},
+		&roomRepo.RoomEntity{},
+		&roomRepo.RoomMemberEntity{},
+	); err != nil {
+		panic(err)
+	}
+
 	stockRepo := stockRepo.NewStockRepository(db)
 	stockService := stockApp.NewStockService(stockRepo)
 
@@ -42,6 +60,11 @@ func NewContainer(db *gorm.DB) *Container {
 
 	redisService := redis.NewRedisService()
 
+	feeModel, err := newFeeModelFromEnv()
+	if err != nil {
+		panic(err)
+	}
+
 	gmSessionRepository := gmSessionRepo.NewRepository(redisService)
 	gmSessionService := gmSessionApp.NewService(gmSessionRepository)
 
@@ -53,6 +76,10 @@ func NewContainer(db *gorm.DB) *Container {
 		aiModel,
 		gmSessionService,
 		tr,
+		feeModel,
+		challengeRepo.NewRepository(db),
+		roomRepo.NewRepository(db),
+		events.NewRedisEventBus(redisService),
 	)
 
 	return &Container{
@@ -63,3 +90,29 @@ func NewContainer(db *gorm.DB) *Container {
 		TaskRunner:         tr,
 	}
 }
+
+// newFeeModelFromEnv reads the trading fee configuration from TRADING_FEE_MODEL,
+// TRADING_FEE_VALUE and TRADING_FEE_MIN, documented in .env.example. Defaults
+// to 0.1% of the trade's value with a $1 minimum.
+func newFeeModelFromEnv() (gameSessionApp.FeeModel, error) {
+	kind := getEnvOrDefault("TRADING_FEE_MODEL", "percentage")
+
+	value, err := strconv.ParseFloat(getEnvOrDefault("TRADING_FEE_VALUE", "0.001"), 64)
+	if err != nil {
+		return nil, err
+	}
+
+	minimum, err := strconv.ParseFloat(getEnvOrDefault("TRADING_FEE_MIN", "1"), 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return gameSessionApp.NewFeeModel(kind, value, minimum)
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -84,7 +84,6 @@ func main() {
 		container.StockService,
 		container.CategoryService,
 		container.GameSessionService,
-		container.GMSessionService,
 	)
 
 	handler := router.SetupRoutes()
@@ -0,0 +1,20 @@
+package challenge
+
+import (
+	"backend/domain/game_session"
+	"backend/domain/gm_session"
+)
+
+// DateLayout is the format of a challenge's date, one challenge per UTC day.
+const DateLayout = "2006-01-02"
+
+// DailyChallenge is the scenario every challenge session of a day plays.
+// WeekData is the GM output as crafted, fake headlines included, so it is
+// never sent to players.
+type DailyChallenge struct {
+	Date       string                            `json:"date"`
+	Categories []string                          `json:"categories"`
+	Rules      game_session.GameRules            `json:"rules"`
+	WeekData   map[string]*gm_session.GMWeekData `json:"-"`
+	CreatedAt  string                            `json:"created_at"`
+}
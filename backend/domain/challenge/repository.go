@@ -0,0 +1,7 @@
+package challenge
+
+type Repository interface {
+	// Create stores the challenge unless one already exists for its date.
+	Create(challenge *DailyChallenge) error
+	FindByDate(date string) (*DailyChallenge, error)
+}
@@ -0,0 +1,15 @@
+package game_session
+
+import "encoding/json"
+
+// Archive keeps a finished session's results permanently, once its Redis
+// metadata and GM data have expired.
+type Archive struct {
+	SessionID   string     `json:"session_id"`
+	ResultToken string     `json:"result_token"`
+	Result      GameResult `json:"result"`
+	// WeekData is the GM data of every week, encoded by the application
+	// layer since the GM types depend on this package.
+	WeekData   json.RawMessage `json:"week_data"`
+	ArchivedAt string          `json:"archived_at"`
+}
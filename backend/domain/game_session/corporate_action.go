@@ -0,0 +1,28 @@
+package game_session
+
+import "backend/pkg/money"
+
+type CorporateActionType string
+
+const (
+	ActionDividend     CorporateActionType = "dividend"
+	ActionSplit        CorporateActionType = "split"
+	ActionReverseSplit CorporateActionType = "reverse_split"
+)
+
+func (t CorporateActionType) IsValid() bool {
+	return t == ActionDividend || t == ActionSplit || t == ActionReverseSplit
+}
+
+// CorporateActionFill records a dividend or split applied to a holding. Cash
+// is the resulting change in cash: the dividend received, or paid on a short
+// position, or the settlement of the fraction of a share left over by a
+// reverse split.
+type CorporateActionFill struct {
+	Ticker         string              `json:"ticker"`
+	Week           int                 `json:"week"`
+	Type           CorporateActionType `json:"type"`
+	QuantityBefore int                 `json:"quantity_before"`
+	QuantityAfter  int                 `json:"quantity_after"`
+	Cash           money.Money         `json:"cash"`
+}
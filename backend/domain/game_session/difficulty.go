@@ -0,0 +1,89 @@
+package game_session
+
+import (
+	"backend/pkg/money"
+	"math"
+)
+
+type Difficulty string
+
+const (
+	DifficultyEasy   Difficulty = "easy"
+	DifficultyNormal Difficulty = "normal"
+	DifficultyHard   Difficulty = "hard"
+	DifficultyExpert Difficulty = "expert"
+)
+
+func (d Difficulty) IsValid() bool {
+	switch d {
+	case DifficultyEasy, DifficultyNormal, DifficultyHard, DifficultyExpert:
+		return true
+	default:
+		return false
+	}
+}
+
+// DifficultyProfile describes how hard the Game Master makes a scenario.
+type DifficultyProfile struct {
+	StartingCash money.Money
+	// FakeHeadlineRatio is the share of each week's headlines that are fake.
+	FakeHeadlineRatio float64
+	// MaxWeeklyMove is the largest weekly price move without a headline to justify it.
+	MaxWeeklyMove float64
+	// MaxHeadlineMove is the hard limit on a weekly move, even when a headline justifies it.
+	MaxHeadlineMove float64
+	// MisleadingActionRatio is the share of analyst actions that point the wrong way.
+	MisleadingActionRatio float64
+}
+
+var difficultyProfiles = map[Difficulty]DifficultyProfile{
+	DifficultyEasy: {
+		StartingCash:          money.FromCents(1500000),
+		FakeHeadlineRatio:     0.2,
+		MaxWeeklyMove:         0.08,
+		MaxHeadlineMove:       0.2,
+		MisleadingActionRatio: 0,
+	},
+	DifficultyNormal: {
+		StartingCash:          money.FromCents(1000000),
+		FakeHeadlineRatio:     0.34,
+		MaxWeeklyMove:         0.1,
+		MaxHeadlineMove:       0.3,
+		MisleadingActionRatio: 0.1,
+	},
+	DifficultyHard: {
+		StartingCash:          money.FromCents(750000),
+		FakeHeadlineRatio:     0.5,
+		MaxWeeklyMove:         0.15,
+		MaxHeadlineMove:       0.45,
+		MisleadingActionRatio: 0.25,
+	},
+	DifficultyExpert: {
+		StartingCash:          money.FromCents(500000),
+		FakeHeadlineRatio:     0.67,
+		MaxWeeklyMove:         0.25,
+		MaxHeadlineMove:       0.6,
+		MisleadingActionRatio: 0.4,
+	},
+}
+
+// Profile returns the settings for the difficulty, falling back to normal.
+func (d Difficulty) Profile() DifficultyProfile {
+	if profile, ok := difficultyProfiles[d]; ok {
+		return profile
+	}
+	return difficultyProfiles[DifficultyNormal]
+}
+
+// FakeHeadlines is how many of the given number of weekly headlines are fake.
+// There is always at least one fake and one genuine headline.
+func (p DifficultyProfile) FakeHeadlines(headlines int) int {
+	fakes := int(math.Round(p.FakeHeadlineRatio * float64(headlines)))
+	if fakes > headlines-1 {
+		fakes = headlines - 1
+	}
+	if fakes < 1 {
+		fakes = 1
+	}
+	return fakes
+}
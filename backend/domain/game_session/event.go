@@ -0,0 +1,50 @@
+package game_session
+
+import "context"
+
+// EventType is the kind of change a session event announces.
+type EventType string
+
+const (
+	EventCraftingProgress  EventType = "crafting_progress"
+	EventCraftingSucceeded EventType = "crafting_succeeded"
+	EventCraftingFailed    EventType = "crafting_failed"
+	EventWeekAdvanced      EventType = "week_advanced"
+	EventOrderFilled       EventType = "order_filled"
+	EventSessionFinished   EventType = "session_finished"
+	EventSessionExpiring   EventType = "session_expiring"
+)
+
+// CraftingStep is the stage a session's scenario is at while being crafted.
+type CraftingStep string
+
+const (
+	StepCategories CraftingStep = "categories"
+	StepStocks     CraftingStep = "stocks"
+	StepScenario   CraftingStep = "scenario"
+	StepSaving     CraftingStep = "saving"
+)
+
+// Event is a change to a session pushed to its player as it happens.
+type Event struct {
+	Type      EventType    `json:"type"`
+	SessionID string       `json:"-"`
+	Step      CraftingStep `json:"step,omitempty"`
+	Week      int          `json:"week,omitempty"`
+	Day       int          `json:"day,omitempty"`
+	// Trades are the fills of the limit orders, protections and buy-ins
+	// executed automatically.
+	Trades    []Trade `json:"trades,omitempty"`
+	Message   string  `json:"message,omitempty"`
+	ExpiresAt string  `json:"expires_at,omitempty"`
+	CreatedAt string  `json:"created_at"`
+}
+
+// EventBus carries session events between the API instances, so a player
+// receives them whichever instance handled the change.
+type EventBus interface {
+	Publish(event Event) error
+	// Subscribe delivers the session's events until ctx is done, then closes
+	// the returned channel.
+	Subscribe(ctx context.Context, sessionID string) (<-chan Event, error)
+}
@@ -0,0 +1,35 @@
+package game_session
+
+// HeadlineFlag is the headline a player marked as fake during a week.
+type HeadlineFlag struct {
+	Week  int `json:"week"`
+	Index int `json:"index"`
+}
+
+// HeadlineReveal discloses a finished week's fake headlines and whether the
+// player flagged one of them.
+type HeadlineReveal struct {
+	Week          int   `json:"week"`
+	FakeHeadlines []int `json:"fake_headlines"`
+	Flagged       *int  `json:"flagged,omitempty"`
+	Detected      bool  `json:"detected"`
+}
+
+// NewHeadlineReveal checks the player's flag for the week, if any, against its
+// fake headlines.
+func NewHeadlineReveal(week int, fakes []int, flags []HeadlineFlag) HeadlineReveal {
+	reveal := HeadlineReveal{Week: week, FakeHeadlines: fakes}
+	for _, flag := range flags {
+		if flag.Week != week {
+			continue
+		}
+		index := flag.Index
+		reveal.Flagged = &index
+		for _, fake := range fakes {
+			if fake == index {
+				reveal.Detected = true
+			}
+		}
+	}
+	return reveal
+}
@@ -0,0 +1,101 @@
+package game_session
+
+import "time"
+
+// LeaderboardMetric is what the leaderboard ranks finished sessions by.
+type LeaderboardMetric string
+
+const (
+	MetricReturn      LeaderboardMetric = "return"
+	MetricAlpha       LeaderboardMetric = "alpha"
+	MetricEfficiency  LeaderboardMetric = "efficiency"
+	MetricSharpe      LeaderboardMetric = "sharpe"
+	MetricMaxDrawdown LeaderboardMetric = "drawdown"
+	MetricVolatility  LeaderboardMetric = "volatility"
+)
+
+func (m LeaderboardMetric) IsValid() bool {
+	switch m {
+	case MetricReturn, MetricAlpha, MetricEfficiency, MetricSharpe, MetricMaxDrawdown, MetricVolatility:
+		return true
+	default:
+		return false
+	}
+}
+
+// LowerIsBetter reports whether sessions rank higher the smaller the metric is.
+func (m LeaderboardMetric) LowerIsBetter() bool {
+	return m == MetricMaxDrawdown || m == MetricVolatility
+}
+
+// LeaderboardWindow limits the leaderboard to sessions finished recently.
+type LeaderboardWindow string
+
+const (
+	WindowToday LeaderboardWindow = "today"
+	WindowWeek  LeaderboardWindow = "week"
+	WindowMonth LeaderboardWindow = "month"
+	WindowAll   LeaderboardWindow = "all"
+)
+
+func (w LeaderboardWindow) IsValid() bool {
+	switch w {
+	case WindowToday, WindowWeek, WindowMonth, WindowAll:
+		return true
+	default:
+		return false
+	}
+}
+
+// Since returns when the window containing now started, in UTC, or the zero
+// time for all time. Weeks start on Monday.
+func (w LeaderboardWindow) Since(now time.Time) time.Time {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch w {
+	case WindowToday:
+		return today
+	case WindowWeek:
+		return today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	case WindowMonth:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Time{}
+	}
+}
+
+type LeaderboardQuery struct {
+	Page     int
+	PageSize int
+	Metric   LeaderboardMetric
+	Window   LeaderboardWindow
+	// Categories keeps the sessions played on all of the given categories.
+	Categories []string
+	Difficulty Difficulty
+	// ChallengeDate keeps only the sessions that played that day's challenge.
+	ChallengeDate string
+}
+
+type LeaderboardEntry struct {
+	Rank int `json:"rank"`
+	GameSession
+}
+
+// RankResult places one finished session on the all-time leaderboard.
+type RankResult struct {
+	Metric LeaderboardMetric `json:"metric"`
+	Rank   int               `json:"rank"`
+	Total  int64             `json:"total"`
+	// Percentile is the share of the other sessions ranked below this one, from 0 to 100.
+	Percentile float64           `json:"percentile"`
+	Entry      LeaderboardEntry  `json:"entry"`
+	Above      *LeaderboardEntry `json:"above,omitempty"`
+	Below      *LeaderboardEntry `json:"below,omitempty"`
+}
+
+type LeaderboardPage struct {
+	Entries  []LeaderboardEntry `json:"entries"`
+	Total    int64              `json:"total"`
+	Page     int                `json:"page"`
+	PageSize int                `json:"page_size"`
+}
@@ -1,14 +1,15 @@
 package game_session
 
+import (
+	"backend/pkg/money"
+	"fmt"
+)
+
 type GameSessionStatus string
 
 const (
 	StatusStarting GameSessionStatus = "starting"
 	StatusWeek1    GameSessionStatus = "week1"
-	StatusWeek2    GameSessionStatus = "week2"
-	StatusWeek3    GameSessionStatus = "week3"
-	StatusWeek4    GameSessionStatus = "week4"
-	StatusWeek5    GameSessionStatus = "week5"
 	StatusFinished GameSessionStatus = "finished"
 	StatusExpired  GameSessionStatus = "expired"
 )
@@ -21,23 +22,136 @@ func (s GameSessionStatus) IsFinished() bool {
 	return s == StatusFinished || s == StatusExpired
 }
 
+// GameMode tells a session playing its own scenario from one playing the
+// daily challenge shared by every player of the day, or one playing in a room
+// alongside other players.
+type GameMode string
+
+const (
+	ModeClassic   GameMode = "classic"
+	ModeChallenge GameMode = "challenge"
+	ModeRoom      GameMode = "room"
+)
+
+// WeekStatus is the status of a session playing the given week.
+func WeekStatus(week int) GameSessionStatus {
+	return GameSessionStatus(fmt.Sprintf("week%d", week))
+}
+
+// Week returns the week being played, or false when the session is not in a
+// playable week.
+func (s GameSessionStatus) Week() (int, bool) {
+	var week int
+	if _, err := fmt.Sscanf(string(s), "week%d", &week); err != nil || week < 1 {
+		return 0, false
+	}
+	return week, true
+}
+
+// HoldingInfo is a position in one stock. Short positions have a negative
+// Quantity, TotalSpent holding the proceeds received when opening them and
+// Collateral the cash reserved to cover them.
 type HoldingInfo struct {
-	Quantity   int     `json:"quantity"`
-	TotalSpent float64 `json:"total_spent"`
+	Quantity   int         `json:"quantity"`
+	TotalSpent money.Money `json:"total_spent"`
+	Collateral money.Money `json:"collateral,omitempty"`
+	// StopLoss and TakeProfit are price thresholds, zero when not set.
+	StopLoss   money.Money `json:"stop_loss,omitempty"`
+	TakeProfit money.Money `json:"take_profit,omitempty"`
+	// ProtectedQuantity is how many shares a triggered threshold sells, zero meaning the whole position.
+	ProtectedQuantity int `json:"protected_quantity,omitempty"`
+	// RealizedPnL is the profit locked in by closing shares of the position, net of fees.
+	RealizedPnL money.Money `json:"realized_pnl,omitempty"`
+}
+
+func (h HoldingInfo) IsShort() bool {
+	return h.Quantity < 0
+}
+
+// Value is what the position is worth at the given price: the market value of
+// a long position, or the collateral left after buying back a short one.
+func (h HoldingInfo) Value(price money.Money) money.Money {
+	if h.IsShort() {
+		return h.Collateral + price.Mul(h.Quantity)
+	}
+	return price.Mul(h.Quantity)
+}
+
+func (h HoldingInfo) HasProtection() bool {
+	return h.StopLoss > 0 || h.TakeProfit > 0
+}
+
+func (h *HoldingInfo) ClearProtection() {
+	h.StopLoss = 0
+	h.TakeProfit = 0
+	h.ProtectedQuantity = 0
+}
+
+type ProtectionReason string
+
+const (
+	ReasonStopLoss   ProtectionReason = "stop_loss"
+	ReasonTakeProfit ProtectionReason = "take_profit"
+	ReasonBuyIn      ProtectionReason = "buy_in"
+)
+
+// ProtectionFill records a position closed automatically by a stop-loss,
+// take-profit or forced short buy-in.
+type ProtectionFill struct {
+	Ticker   string           `json:"ticker"`
+	Week     int              `json:"week"`
+	Quantity int              `json:"quantity"`
+	Price    money.Money      `json:"price"`
+	Reason   ProtectionReason `json:"reason"`
 }
 
 type SessionMetadata struct {
-	Holdings map[string]HoldingInfo `json:"holdings"`
+	Holdings         map[string]HoldingInfo `json:"holdings"`
+	Orders           []LimitOrder           `json:"orders,omitempty"`
+	ProtectionFills  []ProtectionFill       `json:"protection_fills,omitempty"`
+	HeadlineFlags    []HeadlineFlag         `json:"headline_flags,omitempty"`
+	HeadlineReveals  []HeadlineReveal       `json:"headline_reveals,omitempty"`
+	CorporateActions []CorporateActionFill  `json:"corporate_actions,omitempty"`
 }
 
 type GameSession struct {
-	SessionID     string            `json:"session_id"`
-	Username      string            `json:"username"`
-	Cash          float64           `json:"cash"`
-	HoldingsValue float64           `json:"holdings_value"`
-	TotalBalance  float64           `json:"total_balance"`
-	Status        GameSessionStatus `json:"status"`
-	CreatedAt     string            `json:"created_at"`
-	UpdatedAt     string            `json:"updated_at"`
-	Metadata      *SessionMetadata  `json:"metadata,omitempty"`
+	SessionID     string      `json:"session_id"`
+	Username      string      `json:"username"`
+	Cash          money.Money `json:"cash"`
+	HoldingsValue money.Money `json:"holdings_value"`
+	TotalBalance  money.Money `json:"total_balance"`
+	FeesPaid      money.Money `json:"fees_paid"`
+	Rules         GameRules   `json:"rules"`
+	Categories    []string    `json:"categories"`
+	Mode          GameMode    `json:"mode"`
+	// ChallengeDate is the date of the daily challenge played, if any.
+	ChallengeDate string `json:"challenge_date,omitempty"`
+	// RoomCode is the join code of the room the session plays in, if any.
+	RoomCode string `json:"room_code,omitempty"`
+	// Return, BenchmarkReturn and Alpha are set when the session ends: the
+	// player's return on the starting cash, the return of an equal-weight
+	// buy-and-hold of the stocks in play, and the difference between the two.
+	Return          float64 `json:"return"`
+	BenchmarkReturn float64 `json:"benchmark_return"`
+	Alpha           float64 `json:"alpha"`
+	// Efficiency is the ending balance as a fraction of the hindsight-optimal
+	// one, at most 1 even when shorting beat the long-only optimum.
+	Efficiency float64 `json:"efficiency"`
+	// MaxDrawdown, Volatility and SharpeRatio measure the risk taken, from the
+	// balance at the close of every week.
+	MaxDrawdown float64 `json:"max_drawdown"`
+	Volatility  float64 `json:"volatility"`
+	SharpeRatio float64 `json:"sharpe_ratio"`
+	// DetectionScore is the share of weeks in which the player flagged a fake headline.
+	DetectionScore float64           `json:"detection_score"`
+	Status         GameSessionStatus `json:"status"`
+	// Day is the trading day within the week being played, from 1 to TradingDaysPerWeek.
+	Day        int    `json:"day"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+	FinishedAt string `json:"finished_at,omitempty"`
+	// ResultToken identifies the finished session's results and can be shared,
+	// unlike the session ID.
+	ResultToken string           `json:"result_token,omitempty"`
+	Metadata    *SessionMetadata `json:"metadata,omitempty"`
 }
@@ -0,0 +1,53 @@
+package game_session
+
+import "backend/pkg/money"
+
+type OrderSide string
+
+const (
+	OrderSideBuy  OrderSide = "buy"
+	OrderSideSell OrderSide = "sell"
+)
+
+func (s OrderSide) IsValid() bool {
+	return s == OrderSideBuy || s == OrderSideSell
+}
+
+type OrderStatus string
+
+const (
+	OrderStatusOpen      OrderStatus = "open"
+	OrderStatusFilled    OrderStatus = "filled"
+	OrderStatusCancelled OrderStatus = "cancelled"
+	OrderStatusExpired   OrderStatus = "expired"
+)
+
+// LimitOrder is a pending buy/sell that executes when the price on a later
+// trading day reaches the limit price.
+type LimitOrder struct {
+	ID         string      `json:"id"`
+	Ticker     string      `json:"ticker"`
+	Side       OrderSide   `json:"side"`
+	Quantity   int         `json:"quantity"`
+	LimitPrice money.Money `json:"limit_price"`
+	PlacedWeek int         `json:"placed_week"`
+	ExpiryWeek int         `json:"expiry_week"`
+	Status     OrderStatus `json:"status"`
+	FilledWeek int         `json:"filled_week,omitempty"`
+	FillPrice  money.Money `json:"fill_price,omitempty"`
+}
+
+// Matches reports whether the order can execute at the given price.
+func (o *LimitOrder) Matches(price money.Money) bool {
+	if o.Side == OrderSideBuy {
+		return price <= o.LimitPrice
+	}
+	return price >= o.LimitPrice
+}
+
+// MarketOrder is a buy or sell to execute immediately at the current week's price.
+type MarketOrder struct {
+	Ticker   string    `json:"ticker"`
+	Side     OrderSide `json:"side"`
+	Quantity int       `json:"quantity"`
+}
@@ -0,0 +1,30 @@
+package game_session
+
+import "backend/pkg/money"
+
+// Position is a holding valued at the current week's prices.
+type Position struct {
+	Ticker       string      `json:"ticker"`
+	CompanyName  string      `json:"company_name"`
+	Category     string      `json:"category"`
+	Quantity     int         `json:"quantity"`
+	CurrentPrice money.Money `json:"current_price"`
+	// MarketValue is negative for short positions.
+	MarketValue   money.Money `json:"market_value"`
+	AverageCost   money.Money `json:"average_cost"`
+	UnrealizedPnL money.Money `json:"unrealized_pnl"`
+	RealizedPnL   money.Money `json:"realized_pnl"`
+	// Weight is the market value as a fraction of the total balance.
+	Weight float64 `json:"weight"`
+}
+
+type Portfolio struct {
+	Week          int         `json:"week"`
+	Day           int         `json:"day"`
+	Cash          money.Money `json:"cash"`
+	HoldingsValue money.Money `json:"holdings_value"`
+	TotalBalance  money.Money `json:"total_balance"`
+	UnrealizedPnL money.Money `json:"unrealized_pnl"`
+	RealizedPnL   money.Money `json:"realized_pnl"`
+	Positions     []Position  `json:"positions"`
+}
@@ -1,18 +1,52 @@
 package game_session
 
+import "time"
+
 type GameSessionTx interface {
 	Commit() error
 	Rollback() error
 	GetSession() *GameSession
 	Update(*GameSession) error
+	RecordTrade(*Trade) error
+	RecordSnapshot(*Snapshot) error
+	Archive(*Archive) error
 }
 
 type Repository interface {
 	Save(*GameSession) error
 	FindBySessionID(string) (*GameSession, error)
-	FindLeaderboardTop10(page, pageSize int) ([]GameSession, error)
+	FindLeaderboard(query LeaderboardQuery) ([]GameSession, int64, error)
+	SetCategories(sessionID string, categories []string) error
+	// FindByRoomCode returns the sessions playing in the given room, finished
+	// ones included, highest balance first.
+	FindByRoomCode(code string) ([]GameSession, error)
+	// ExpiresAt returns when the session expires if it is not played until
+	// then, or the zero time if it does not expire.
+	ExpiresAt(sessionID string) (time.Time, error)
+	// Touch pushes back the expiry of a session that is waiting on others
+	// rather than being played.
+	Touch(sessionID string) error
+	// SaveStreamTicket stores a ticket that opens the session's event stream
+	// once within ttl, so the session ID never appears in a URL.
+	SaveStreamTicket(ticket string, sessionID string, ttl time.Duration) error
+	// TakeStreamTicket returns the session ID the ticket was issued for and
+	// invalidates the ticket.
+	TakeStreamTicket(ticket string) (string, error)
+	// FindRankByResultToken returns the 1-based all-time position of the
+	// finished session with the given result token, and the number of
+	// finished sessions.
+	FindRankByResultToken(token string, metric LeaderboardMetric) (int, int64, error)
+	// FindLeaderboardRange returns finished sessions in all-time leaderboard
+	// order, skipping the first offset.
+	FindLeaderboardRange(metric LeaderboardMetric, offset, limit int) ([]GameSession, error)
 	BeginTransaction(sessionID string) (GameSessionTx, error)
 	UpdateGameCraftingStatus(sessionID string, success bool) error
+	// ReleaseChallengeAttempt frees the day's challenge attempt held by a
+	// challenge session that failed to craft, so its player can try again.
+	ReleaseChallengeAttempt(sessionID string) error
+	FindTradesBySessionID(sessionID string) ([]Trade, error)
+	FindSnapshotsBySessionID(sessionID string) ([]Snapshot, error)
+	FindArchiveByResultToken(token string) (*Archive, error)
 }
 
 type Pagination struct {
@@ -0,0 +1,27 @@
+package game_session
+
+import "backend/pkg/money"
+
+// OptimalTrade is a trade of the hindsight-optimal strategy.
+type OptimalTrade struct {
+	Week     int         `json:"week"`
+	Day      int         `json:"day"`
+	Ticker   string      `json:"ticker"`
+	Side     OrderSide   `json:"side"`
+	Quantity int         `json:"quantity"`
+	Price    money.Money `json:"price"`
+}
+
+// OptimalStrategyLongOnly names the hindsight-optimal strategy the results are
+// scored against: long positions only, with no fees. Shorting can beat it, so
+// a session's Efficiency is capped at 1.
+const OptimalStrategyLongOnly = "long_only_no_fees"
+
+// GameResult is a finished session together with the best ending balance that
+// could have been reached and the trades that reach it.
+type GameResult struct {
+	GameSession
+	OptimalBalance  money.Money    `json:"optimal_balance"`
+	OptimalTrades   []OptimalTrade `json:"optimal_trades"`
+	OptimalStrategy string         `json:"optimal_strategy"`
+}
@@ -0,0 +1,84 @@
+package game_session
+
+import (
+	"backend/pkg/errors"
+	"backend/pkg/money"
+	"fmt"
+)
+
+const (
+	MinWeeks = 2
+	MaxWeeks = 10
+
+	// CategoriesPerSession is how many stock categories every game is built on.
+	CategoriesPerSession = 3
+
+	// TradingDaysPerWeek is how many daily prices every week is played over.
+	TradingDaysPerWeek = 5
+)
+
+// GameRules are the parameters a session is played with. They are chosen at
+// creation and stored with the session.
+type GameRules struct {
+	Weeks             int         `json:"weeks"`
+	StartingCash      money.Money `json:"starting_cash"`
+	StocksPerCategory int         `json:"stocks_per_category"`
+	HeadlinesPerWeek  int         `json:"headlines_per_week"`
+	Difficulty        Difficulty  `json:"difficulty"`
+}
+
+func DefaultRules() GameRules {
+	return GameRules{
+		Weeks:             5,
+		StartingCash:      money.FromCents(1000000),
+		StocksPerCategory: 4,
+		HeadlinesPerWeek:  3,
+		Difficulty:        DifficultyNormal,
+	}
+}
+
+// WithDefaults fills the unset fields from DefaultRules. The starting cash
+// defaults to the one of the chosen difficulty.
+func (r GameRules) WithDefaults() GameRules {
+	defaults := DefaultRules()
+	if r.Difficulty == "" {
+		r.Difficulty = defaults.Difficulty
+	}
+	if r.Weeks == 0 {
+		r.Weeks = defaults.Weeks
+	}
+	if r.StartingCash.IsZero() {
+		r.StartingCash = r.Difficulty.Profile().StartingCash
+	}
+	if r.StocksPerCategory == 0 {
+		r.StocksPerCategory = defaults.StocksPerCategory
+	}
+	if r.HeadlinesPerWeek == 0 {
+		r.HeadlinesPerWeek = defaults.HeadlinesPerWeek
+	}
+	return r
+}
+
+func (r GameRules) Validate() error {
+	if !r.Difficulty.IsValid() {
+		return errors.New(errors.ErrInvalidInput, fmt.Sprintf("invalid difficulty: %s", r.Difficulty))
+	}
+	if r.Weeks < MinWeeks || r.Weeks > MaxWeeks {
+		return errors.New(errors.ErrInvalidInput, fmt.Sprintf("weeks must be between %d and %d", MinWeeks, MaxWeeks))
+	}
+	if r.StartingCash < money.FromCents(100000) || r.StartingCash > money.FromCents(100000000) {
+		return errors.New(errors.ErrInvalidInput, "starting cash must be between 1000.00 and 1000000.00")
+	}
+	if r.StocksPerCategory < 1 || r.StocksPerCategory > 6 {
+		return errors.New(errors.ErrInvalidInput, "stocks per category must be between 1 and 6")
+	}
+	if r.HeadlinesPerWeek < 2 || r.HeadlinesPerWeek > 6 {
+		return errors.New(errors.ErrInvalidInput, "headlines per week must be between 2 and 6")
+	}
+	return nil
+}
+
+// StockCount is the number of stocks in play every week.
+func (r GameRules) StockCount() int {
+	return r.StocksPerCategory * CategoriesPerSession
+}
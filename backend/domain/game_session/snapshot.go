@@ -0,0 +1,23 @@
+package game_session
+
+import "backend/pkg/money"
+
+// SnapshotPosition is a position as it stood when a snapshot was taken.
+type SnapshotPosition struct {
+	Ticker   string      `json:"ticker"`
+	Quantity int         `json:"quantity"`
+	Price    money.Money `json:"price"`
+	Value    money.Money `json:"value"`
+}
+
+// Snapshot is the state of a session at the close of a week, the points of
+// its equity curve.
+type Snapshot struct {
+	SessionID     string             `json:"session_id"`
+	Week          int                `json:"week"`
+	Cash          money.Money        `json:"cash"`
+	HoldingsValue money.Money        `json:"holdings_value"`
+	TotalBalance  money.Money        `json:"total_balance"`
+	Positions     []SnapshotPosition `json:"positions"`
+	CreatedAt     string             `json:"created_at"`
+}
@@ -0,0 +1,54 @@
+package game_session
+
+import "backend/pkg/money"
+
+type TradeSource string
+
+const (
+	TradeSourceManual      TradeSource = "manual"
+	TradeSourceLimitOrder  TradeSource = "limit_order"
+	TradeSourceLiquidation TradeSource = "liquidation"
+)
+
+// TradeSourceFor maps the reason of an automatic position close to the trade
+// source recorded in the ledger.
+func TradeSourceFor(reason ProtectionReason) TradeSource {
+	return TradeSource(reason)
+}
+
+// Trade is an executed buy or sell recorded in the session's ledger.
+type Trade struct {
+	ID         string      `json:"id"`
+	SessionID  string      `json:"session_id"`
+	Week       int         `json:"week"`
+	Day        int         `json:"day"`
+	Ticker     string      `json:"ticker"`
+	Side       OrderSide   `json:"side"`
+	Quantity   int         `json:"quantity"`
+	Price      money.Money `json:"price"`
+	Fee        money.Money `json:"fee"`
+	CashBefore money.Money `json:"cash_before"`
+	CashAfter  money.Money `json:"cash_after"`
+	Source     TradeSource `json:"source"`
+	CreatedAt  string      `json:"created_at"`
+}
+
+// TradeQuote is the projected outcome of a trade that has not been executed.
+type TradeQuote struct {
+	Ticker   string      `json:"ticker"`
+	Side     OrderSide   `json:"side"`
+	Quantity int         `json:"quantity"`
+	Price    money.Money `json:"price"`
+	Fee      money.Money `json:"fee"`
+	// CashChange is how much cash the trade adds, fees included; negative when
+	// it costs cash, including the margin reserved when opening a short.
+	CashChange    money.Money `json:"cash_change"`
+	Cash          money.Money `json:"cash"`
+	HoldingsValue money.Money `json:"holdings_value"`
+	TotalBalance  money.Money `json:"total_balance"`
+	// PositionQuantity is the resulting position, negative when short.
+	PositionQuantity int `json:"position_quantity"`
+	// AverageCost is the cost basis per share of the resulting position, or the
+	// proceeds per share of a short one.
+	AverageCost money.Money `json:"average_cost"`
+}
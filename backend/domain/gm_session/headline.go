@@ -0,0 +1,44 @@
+package gm_session
+
+import "encoding/json"
+
+type HeadlineDirection string
+
+const (
+	DirectionUp    HeadlineDirection = "up"
+	DirectionDown  HeadlineDirection = "down"
+	DirectionMixed HeadlineDirection = "mixed"
+)
+
+func (d HeadlineDirection) IsValid() bool {
+	return d == DirectionUp || d == DirectionDown || d == DirectionMixed
+}
+
+// Headline is a piece of weekly news with the stocks it is expected to move.
+// Fake marks misleading headlines; it is only known to the server and is
+// stripped before the week data is stored.
+type Headline struct {
+	Text       string            `json:"text"`
+	Tickers    []string          `json:"tickers,omitempty"`
+	Categories []string          `json:"categories,omitempty"`
+	Direction  HeadlineDirection `json:"direction,omitempty"`
+	Fake       bool              `json:"fake,omitempty"`
+}
+
+// UnmarshalJSON also accepts a bare string, the format headlines had before
+// they carried their impact, so week data stored earlier still loads.
+func (h *Headline) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*h = Headline{Text: text}
+		return nil
+	}
+
+	type headline Headline
+	var decoded headline
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*h = Headline(decoded)
+	return nil
+}
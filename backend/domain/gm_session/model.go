@@ -1,25 +1,65 @@
 package gm_session
 
 import (
+	"backend/domain/game_session"
 	"backend/domain/stock"
+	"backend/pkg/money"
 	"context"
 )
 
 type AI interface {
-	GetGMResponse(ctx context.Context, categories []string, stocks []stock.Stock) (map[string]*GMWeekData, error)
+	GetGMResponse(ctx context.Context, categories []string, stocks []stock.Stock, rules game_session.GameRules) (map[string]*GMWeekData, error)
 }
 
 type GMWeekData struct {
-	Headlines []string           `json:"headlines"`
+	Headlines []Headline         `json:"headlines"`
 	Stocks    []StockWeekInsight `json:"stocks"`
+	// Events are the corporate actions that take effect as the week starts,
+	// before its prices apply.
+	Events []CorporateAction `json:"events,omitempty"`
+}
+
+// CorporateAction is a dividend or split scheduled by the GM. Amount is the
+// dividend per share. Ratio is how many shares one becomes on a split, or how
+// many become one on a reverse split.
+type CorporateAction struct {
+	Ticker string                           `json:"ticker"`
+	Type   game_session.CorporateActionType `json:"type"`
+	Amount money.Money                      `json:"amount,omitempty"`
+	Ratio  int                              `json:"ratio,omitempty"`
 }
 
 type StockWeekInsight struct {
-	Ticker      string  `json:"ticker"`
-	CompanyName string  `json:"companyName"`
-	RatingFrom  string  `json:"rating_from"`
-	RatingTo    string  `json:"rating_to"`
-	Action      string  `json:"action"`
-	Price       float64 `json:"price"`
-	PriceChange float64 `json:"priceChange"`
+	Ticker      string      `json:"ticker"`
+	CompanyName string      `json:"companyName"`
+	Category    string      `json:"category,omitempty"`
+	RatingFrom  string      `json:"rating_from"`
+	RatingTo    string      `json:"rating_to"`
+	Action      string      `json:"action"`
+	Price       money.Money `json:"price"`
+	PriceChange float64     `json:"priceChange"`
+	// DailyPrices are the prices on each trading day of the week, the first
+	// being Price. They are derived from the GM's weekly prices, not generated
+	// by the GM.
+	DailyPrices []money.Money `json:"daily_prices,omitempty"`
+}
+
+// PriceOn is the stock's price on the given trading day of the week. Week
+// data stored without daily prices keeps the weekly price all week.
+func (s StockWeekInsight) PriceOn(day int) money.Money {
+	if day < 1 || day > len(s.DailyPrices) {
+		return s.Price
+	}
+	return s.DailyPrices[day-1]
+}
+
+// OnDay returns a copy of the week data priced at the given trading day.
+func (d *GMWeekData) OnDay(day int) *GMWeekData {
+	priced := *d
+	priced.Stocks = make([]StockWeekInsight, len(d.Stocks))
+	for i, stock := range d.Stocks {
+		stock.Price = stock.PriceOn(day)
+		priced.Stocks[i] = stock
+	}
+	return &priced
 }
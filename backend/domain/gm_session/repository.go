@@ -4,5 +4,7 @@ package gm_session
 type Repository interface {
 	SaveWeekData(sessionID string, week int, data *GMWeekData) error
 	GetWeekData(sessionID string, week int) (*GMWeekData, error)
+	SaveFakeHeadlines(sessionID string, week int, fakes []int) error
+	GetFakeHeadlines(sessionID string, week int) ([]int, error)
 	ClearSessionData(sessionID string) error // optional, for dev cleanup or retries
 }
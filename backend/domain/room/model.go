@@ -0,0 +1,69 @@
+package room
+
+import (
+	"backend/domain/game_session"
+	"backend/domain/gm_session"
+	"backend/pkg/money"
+)
+
+type Status string
+
+const (
+	StatusCrafting Status = "crafting"
+	StatusPlaying  Status = "playing"
+	StatusFinished Status = "finished"
+	StatusFailed   Status = "failed"
+)
+
+// Member is a player of a room. Their session ID is their credential, so it
+// is never sent to other players.
+type Member struct {
+	SessionID string `json:"-"`
+	Username  string `json:"username"`
+	Host      bool   `json:"host"`
+	Ready     bool   `json:"ready"`
+}
+
+// Room is a group of sessions playing the same scenario and advancing week by
+// week together. WeekData is the GM output as crafted, fake headlines
+// included, and is copied into every member's session.
+type Room struct {
+	Code          string                            `json:"code"`
+	HostSessionID string                            `json:"-"`
+	Status        Status                            `json:"status"`
+	Week          int                               `json:"week"`
+	Rules         game_session.GameRules            `json:"rules"`
+	Categories    []string                          `json:"categories"`
+	Members       []Member                          `json:"members"`
+	WeekData      map[string]*gm_session.GMWeekData `json:"-"`
+	CreatedAt     string                            `json:"created_at"`
+}
+
+// AllReady reports whether every member still playing is ready to advance.
+// Members whose session expired or ended are left out, as they will never
+// get ready.
+func (r *Room) AllReady(playing func(Member) bool) bool {
+	count := 0
+	for _, member := range r.Members {
+		if !playing(member) {
+			continue
+		}
+		if !member.Ready {
+			return false
+		}
+		count++
+	}
+	return count > 0
+}
+
+// Standing is a member's place on the room leaderboard.
+type Standing struct {
+	Rank          int                            `json:"rank"`
+	Username      string                         `json:"username"`
+	Cash          money.Money                    `json:"cash"`
+	HoldingsValue money.Money                    `json:"holdings_value"`
+	TotalBalance  money.Money                    `json:"total_balance"`
+	Return        float64                        `json:"return"`
+	Status        game_session.GameSessionStatus `json:"status"`
+	Ready         bool                           `json:"ready"`
+}
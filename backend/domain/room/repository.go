@@ -0,0 +1,23 @@
+package room
+
+// RoomTx changes a room while holding a lock on it, so players joining or
+// getting ready at the same time on any API instance do not miss each other.
+type RoomTx interface {
+	Commit() error
+	Rollback() error
+	GetRoom() *Room
+	// Update saves the room's status, week, categories and week data.
+	Update(room *Room) error
+	AddMember(member Member) error
+	SetReady(sessionID string, ready bool) error
+	ResetReady() error
+}
+
+type Repository interface {
+	Create(room *Room) error
+	FindByCode(code string) (*Room, error)
+	// FindCodeBySessionID returns the code of the room the session is a
+	// member of.
+	FindCodeBySessionID(sessionID string) (string, error)
+	BeginTransaction(code string) (RoomTx, error)
+}
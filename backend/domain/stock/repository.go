@@ -5,5 +5,7 @@ import "context"
 type Repository interface {
 	FindAllStocks(ctx context.Context, params QueryParams) ([]Stock, int64, error)
 	FindBy(filters map[string]any) (*Stock, error)
-	PickStocksForSession(categories []string) ([]Stock, error)
+	// PickStocksForSession picks perCategory stocks of each category, at
+	// random or, given a seed, the same ones for the same seed.
+	PickStocksForSession(categories []string, perCategory int, seed string) ([]Stock, error)
 }
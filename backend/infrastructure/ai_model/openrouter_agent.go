@@ -6,10 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"os"
 	"strings"
 
+	"backend/domain/game_session"
 	"backend/domain/gm_session"
 	"backend/domain/stock"
 )
@@ -43,120 +45,33 @@ type chatMessage struct {
 	Content string `json:"content"`
 }
 
-func sanitizeJSONStrict(raw string) string {
-	var out strings.Builder
-	inString := false
-	escapeNext := false
-	inNumber := false
-
-	for i := 0; i < len(raw); i++ {
-		c := raw[i]
-
-		if escapeNext {
-			out.WriteByte('\\')
-			out.WriteByte(c)
-			escapeNext = false
-			continue
-		}
-
-		if inString {
-			out.WriteByte(c)
-			if c == '\\' {
-				escapeNext = true
-			} else if c == '"' {
-				inString = false
-			}
-		} else if inNumber {
-			if (c >= '0' && c <= '9') || c == '.' || c == '-' {
-				out.WriteByte(c)
-			} else {
-				inNumber = false
-				if c == ',' || c == '}' || c == ']' {
-					out.WriteByte(c)
-				}
-			}
-		} else {
-			switch c {
-			case '"':
-				inString = true
-				out.WriteByte(c)
-			case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-				inNumber = true
-				out.WriteByte(c)
-			case '{', '}', '[', ']', ':', ',':
-				out.WriteByte(c)
-			case ' ', '\n', '\t', '\r':
-				out.WriteByte(c)
-			}
-		}
-	}
-
-	return out.String()
-}
-
+// extractFirstJSONObject returns the first JSON object of the model's reply,
+// leaving out any text or markdown fence around it.
 func extractFirstJSONObject(raw string) (string, error) {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
 		return "", fmt.Errorf("empty response")
 	}
 
-	// Find the first opening brace
 	start := strings.Index(raw, "{")
 	if start == -1 {
 		return "", fmt.Errorf("no JSON object found in response")
 	}
 
-	// Track nested braces
-	braces := 0
-	inString := false
-	escapeNext := false
-
-	for i := start; i < len(raw); i++ {
-		c := raw[i]
-
-		if escapeNext {
-			escapeNext = false
-			continue
-		}
-
-		if inString {
-			if c == '\\' {
-				escapeNext = true
-			} else if c == '"' {
-				inString = false
-			}
-			continue
-		}
-
-		if c == '"' {
-			inString = true
-		} else if c == '{' {
-			braces++
-		} else if c == '}' {
-			braces--
-			if braces == 0 {
-				// Found matching closing brace
-				extracted := raw[start : i+1]
-				sanitized := sanitizeJSONStrict(extracted)
-
-				// Validate JSON structure
-				var js json.RawMessage
-				if err := json.Unmarshal([]byte(sanitized), &js); err != nil {
-					return "", fmt.Errorf("invalid JSON structure: %w\nProblematic content: %s", err, sanitized)
-				}
-
-				return sanitized, nil
-			}
-		}
+	// The decoder stops at the end of the first value, so whatever follows
+	// the object is ignored.
+	var object json.RawMessage
+	if err := json.NewDecoder(strings.NewReader(raw[start:])).Decode(&object); err != nil {
+		return "", fmt.Errorf("invalid JSON structure: %w", err)
 	}
-
-	return "", fmt.Errorf("unclosed JSON object")
+	return string(object), nil
 }
 
 func (a *OpenRouterAgent) GetGMResponse(
 	ctx context.Context,
 	categories []string,
 	stocks []stock.Stock,
+	rules game_session.GameRules,
 ) (map[string]*gm_session.GMWeekData, error) {
 	// Prepare template data
 	stocksData := make([]map[string]interface{}, len(stocks))
@@ -170,9 +85,23 @@ func (a *OpenRouterAgent) GetGMResponse(
 		}
 	}
 
+	profile := rules.Difficulty.Profile()
+	fakeHeadlines := profile.FakeHeadlines(rules.HeadlinesPerWeek)
+
 	templateData := map[string]interface{}{
-		"Categories": categories,
-		"stocks":     stocksData,
+		"Categories":       categories,
+		"stocks":           stocksData,
+		"Weeks":            rules.Weeks,
+		"StartingCash":     rules.StartingCash.String(),
+		"StockCount":       len(stocks),
+		"HeadlinesPerWeek": rules.HeadlinesPerWeek,
+		"PlausibleCount":   rules.HeadlinesPerWeek - fakeHeadlines,
+		"FakeHeadlines":    fakeHeadlines,
+		"Difficulty":       string(rules.Difficulty),
+
+		"MaxWeeklyMovePct":     math.Round(profile.MaxWeeklyMove * 100),
+		"MaxHeadlineMovePct":   math.Round(profile.MaxHeadlineMove * 100),
+		"MisleadingActionsPct": math.Round(profile.MisleadingActionRatio * 100),
 	}
 
 	prompt, err := LoadPrompt("infrastructure/ai_model/gm_prompt.txt", templateData)
@@ -221,12 +150,16 @@ func (a *OpenRouterAgent) GetGMResponse(
 		return nil, fmt.Errorf("no response choices returned from API")
 	}
 
-	// Parse the AI response content into weeks data
+	return parseGMResponse(aiResp.Choices[0].Message.Content)
+}
+
+// parseGMResponse reads the weeks out of the model's reply.
+func parseGMResponse(content string) (map[string]*gm_session.GMWeekData, error) {
 	var response struct {
 		Weeks map[string]*gm_session.GMWeekData `json:"weeks"`
 	}
 
-	cleanedContent, err := extractFirstJSONObject(aiResp.Choices[0].Message.Content)
+	cleanedContent, err := extractFirstJSONObject(content)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract first JSON object: %w", err)
 	}
@@ -0,0 +1,112 @@
+package ai_model
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var (
+	elidedLine     = regexp.MustCompile(`(?m)^\s*\.\.\.,?\s*$\n`)
+	elidedList     = regexp.MustCompile(`\[\.\.\.\]`)
+	trailingCommas = regexp.MustCompile(`,(\s*[\]}])`)
+)
+
+// promptExample renders the prompt and returns the reply it shows the model,
+// with the elided entries left out.
+func promptExample(t *testing.T) string {
+	t.Helper()
+
+	prompt, err := LoadPrompt("gm_prompt.txt", map[string]any{
+		"Weeks":            5,
+		"StockCount":       2,
+		"HeadlinesPerWeek": 3,
+		"PlausibleCount":   2,
+		"FakeHeadlines":    1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := strings.Index(prompt, "{\n  \"weeks\"")
+	end := strings.Index(prompt, "IMPORTANT RULES")
+	if start == -1 || end < start {
+		t.Fatal("the prompt's example reply was not found")
+	}
+
+	example := elidedLine.ReplaceAllString(prompt[start:end], "")
+	example = elidedList.ReplaceAllString(example, "[]")
+	return trailingCommas.ReplaceAllString(example, "$1")
+}
+
+func TestParseGMResponseReadsThePromptExample(t *testing.T) {
+	example := promptExample(t)
+
+	replies := map[string]string{
+		"bare":         example,
+		"fenced":       "```json\n" + example + "\n```",
+		"with remarks": "Here is the game:\n" + example + "\nEnjoy the game!",
+	}
+
+	for name, reply := range replies {
+		weeks, err := parseGMResponse(reply)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		week1 := weeks["week1"]
+		if week1 == nil {
+			t.Fatalf("%s: week1 missing from %v", name, weeks)
+		}
+		if len(week1.Headlines) != 3 || len(week1.Stocks) != 1 {
+			t.Fatalf("%s: week1 has %d headlines and %d stocks, want 3 and 1", name, len(week1.Headlines), len(week1.Stocks))
+		}
+		for i, want := range []bool{false, false, true} {
+			if week1.Headlines[i].Fake != want {
+				t.Errorf("%s: headline %d fake = %v, want %v", name, i, week1.Headlines[i].Fake, want)
+			}
+		}
+		if stock := week1.Stocks[0]; stock.Ticker != "AAPL" || stock.Price != 18534 {
+			t.Errorf("%s: stock %s at %s, want AAPL at 185.34", name, stock.Ticker, stock.Price)
+		}
+
+		week2 := weeks["week2"]
+		if week2 == nil || len(week2.Events) != 2 || week2.Events[0].Ratio != 2 || week2.Events[1].Amount != 75 {
+			t.Errorf("%s: week2 events = %+v, want a 2 split and a 0.75 dividend", name, week2)
+		}
+		if weeks["week5"] == nil {
+			t.Errorf("%s: week5 missing", name)
+		}
+	}
+}
+
+func TestExtractFirstJSONObject(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{raw: `{"fake":true,"gone":null,"n":-1.5e2}`, want: `{"fake":true,"gone":null,"n":-1.5e2}`},
+		{raw: "Sure!\n{\"text\":\"a \\\"quoted\\\" {brace}\"} and {\"second\":1}", want: `{"text":"a \"quoted\" {brace}"}`},
+		{raw: "   ", wantErr: true},
+		{raw: "no object here", wantErr: true},
+		{raw: `{"weeks": {"week1": `, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := extractFirstJSONObject(tt.raw)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("extractFirstJSONObject(%q) = %q, want an error", tt.raw, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("extractFirstJSONObject(%q) returned error: %v", tt.raw, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("extractFirstJSONObject(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
@@ -13,7 +13,9 @@ func Connect() (*gorm.DB, error) {
 	if dsn == "" {
 		return nil, ErrMissingDBURL
 	}
-	return gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// TranslateError reports constraint violations as gorm errors, such as
+	// gorm.ErrDuplicatedKey, whatever the driver.
+	return gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 }
 
 var ErrMissingDBURL = errors.New(errors.ErrBadRequest, "missing DATABASE_URL environment variable")
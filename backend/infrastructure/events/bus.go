@@ -0,0 +1,55 @@
+package events
+
+import (
+	"backend/domain/game_session"
+	"backend/infrastructure/redis"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+type redisEventBus struct {
+	redisService redis.RedisService
+}
+
+// NewRedisEventBus publishes session events on Redis, one channel per session.
+func NewRedisEventBus(redisService redis.RedisService) game_session.EventBus {
+	return &redisEventBus{redisService: redisService}
+}
+
+func channelFor(sessionID string) string {
+	return fmt.Sprintf("session:%s:events", sessionID)
+}
+
+func (b *redisEventBus) Publish(event game_session.Event) error {
+	return b.redisService.Publish(context.Background(), channelFor(event.SessionID), event)
+}
+
+func (b *redisEventBus) Subscribe(ctx context.Context, sessionID string) (<-chan game_session.Event, error) {
+	messages, err := b.redisService.Subscribe(ctx, channelFor(sessionID))
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan game_session.Event)
+	go func() {
+		defer close(events)
+		for message := range messages {
+			var event game_session.Event
+			if err := json.Unmarshal(message, &event); err != nil {
+				log.Printf("Error decoding event for session %s: %v", sessionID, err)
+				continue
+			}
+			event.SessionID = sessionID
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
@@ -10,7 +10,18 @@ import (
 type RedisService interface {
 	Set(ctx context.Context, key string, value any, ttl time.Duration) error
 	Get(ctx context.Context, key string, dest any) error
+	// GetDel gets the value and deletes the key at once, so only one caller
+	// ever gets it.
+	GetDel(ctx context.Context, key string, dest any) error
 	Delete(ctx context.Context, key string) error
+	// TTL returns how long the key has left to live, zero when it does not expire.
+	TTL(ctx context.Context, key string) (time.Duration, error)
+	// Expire sets how long the key has left to live.
+	Expire(ctx context.Context, key string, ttl time.Duration) error
+	Publish(ctx context.Context, channel string, message any) error
+	// Subscribe delivers the messages published on the channel until ctx is
+	// done, then closes the returned channel.
+	Subscribe(ctx context.Context, channel string) (<-chan []byte, error)
 	Ping(ctx context.Context) error
 }
 
@@ -47,6 +58,19 @@ func (s *redisService) Get(ctx context.Context, key string, dest any) error {
 	return nil
 }
 
+func (s *redisService) GetDel(ctx context.Context, key string, dest any) error {
+	data, err := GetClient().GetDel(ctx, key).Bytes()
+	if err != nil {
+		return errors.Wrap(errors.ErrNotFound, "failed to get value from Redis", err)
+	}
+
+	if err := json.Unmarshal(data, dest); err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to unmarshal value", err)
+	}
+
+	return nil
+}
+
 func (s *redisService) Delete(ctx context.Context, key string) error {
 	if err := GetClient().Del(ctx, key).Err(); err != nil {
 		return errors.Wrap(errors.ErrInternal, "failed to delete key from Redis", err)
@@ -54,6 +78,80 @@ func (s *redisService) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+func (s *redisService) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := GetClient().TTL(ctx, key).Result()
+	if err != nil {
+		return 0, errors.Wrap(errors.ErrInternal, "failed to get TTL from Redis", err)
+	}
+	// Redis answers -2 for a missing key and -1 for a key without expiry.
+	if ttl == -2 {
+		return 0, errors.New(errors.ErrNotFound, "key not found in Redis")
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func (s *redisService) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	ok, err := GetClient().Expire(ctx, key, ttl).Result()
+	if err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to set TTL in Redis", err)
+	}
+	if !ok {
+		return errors.New(errors.ErrNotFound, "key not found in Redis")
+	}
+	return nil
+}
+
+func (s *redisService) Publish(ctx context.Context, channel string, message any) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to marshal message", err)
+	}
+
+	if err := GetClient().Publish(ctx, channel, data).Err(); err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to publish message to Redis", err)
+	}
+
+	return nil
+}
+
+func (s *redisService) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	pubsub := GetClient().Subscribe(ctx, channel)
+	// Wait for the subscription to be confirmed, so no message published
+	// after Subscribe returns is missed.
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, errors.Wrap(errors.ErrInternal, "failed to subscribe to Redis channel", err)
+	}
+
+	messages := make(chan []byte)
+	go func() {
+		defer close(messages)
+		defer pubsub.Close()
+
+		incoming := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-incoming:
+				if !ok {
+					return
+				}
+				select {
+				case messages <- []byte(msg.Payload):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return messages, nil
+}
+
 func (s *redisService) Ping(ctx context.Context) error {
 	if err := Ping(ctx); err != nil {
 		return errors.Wrap(errors.ErrInternal, "failed to ping Redis", err)
@@ -0,0 +1,63 @@
+package challenge
+
+import (
+	"backend/domain/challenge"
+	"backend/domain/game_session"
+	"backend/domain/gm_session"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+type DailyChallengeEntity struct {
+	Date       string    `gorm:"column:date;primaryKey;type:varchar(10)" json:"date"`
+	Categories string    `gorm:"column:categories;type:varchar(255);not null" json:"categories"`
+	Rules      string    `gorm:"column:rules;type:jsonb;not null" json:"rules"`
+	WeekData   string    `gorm:"column:week_data;type:jsonb;not null" json:"week_data"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+}
+
+func (DailyChallengeEntity) TableName() string {
+	return "daily_challenges"
+}
+
+func ToDomain(e *DailyChallengeEntity) (*challenge.DailyChallenge, error) {
+	if e == nil {
+		return nil, nil
+	}
+	var rules game_session.GameRules
+	if err := json.Unmarshal([]byte(e.Rules), &rules); err != nil {
+		return nil, err
+	}
+	var weekData map[string]*gm_session.GMWeekData
+	if err := json.Unmarshal([]byte(e.WeekData), &weekData); err != nil {
+		return nil, err
+	}
+	return &challenge.DailyChallenge{
+		Date:       e.Date,
+		Categories: strings.Split(e.Categories, ","),
+		Rules:      rules,
+		WeekData:   weekData,
+		CreatedAt:  e.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+func FromDomain(c *challenge.DailyChallenge) (*DailyChallengeEntity, error) {
+	if c == nil {
+		return nil, nil
+	}
+	rules, err := json.Marshal(c.Rules)
+	if err != nil {
+		return nil, err
+	}
+	weekData, err := json.Marshal(c.WeekData)
+	if err != nil {
+		return nil, err
+	}
+	return &DailyChallengeEntity{
+		Date:       c.Date,
+		Categories: strings.Join(c.Categories, ","),
+		Rules:      string(rules),
+		WeekData:   string(weekData),
+	}, nil
+}
@@ -0,0 +1,46 @@
+package challenge
+
+import (
+	"backend/domain/challenge"
+	"backend/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) challenge.Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(c *challenge.DailyChallenge) error {
+	entity, err := FromDomain(c)
+	if err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to encode challenge", err)
+	}
+	// Sessions starting at the same time may craft the day's challenge
+	// concurrently; the first one stored is the one everybody plays.
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(entity).Error; err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to save challenge", err)
+	}
+	return nil
+}
+
+func (r *repository) FindByDate(date string) (*challenge.DailyChallenge, error) {
+	var entity DailyChallengeEntity
+	if err := r.db.Where("date = ?", date).First(&entity).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New(errors.ErrNotFound, "challenge not found")
+		}
+		return nil, errors.Wrap(errors.ErrInternal, "failed to find challenge", err)
+	}
+
+	c, err := ToDomain(&entity)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to decode challenge", err)
+	}
+	return c, nil
+}
@@ -0,0 +1,53 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"encoding/json"
+	"time"
+)
+
+type GameSessionArchiveEntity struct {
+	SessionID   string    `gorm:"column:session_id;primaryKey;type:varchar(64)" json:"session_id"`
+	ResultToken string    `gorm:"column:result_token;type:varchar(64);not null;uniqueIndex" json:"result_token"`
+	Result      string    `gorm:"column:result;type:jsonb;not null" json:"result"`
+	WeekData    string    `gorm:"column:week_data;type:jsonb;not null" json:"week_data"`
+	ArchivedAt  time.Time `gorm:"column:archived_at;autoCreateTime" json:"archived_at"`
+}
+
+func (GameSessionArchiveEntity) TableName() string {
+	return "game_session_archives"
+}
+
+func ArchiveToDomain(e *GameSessionArchiveEntity) (*game_session.Archive, error) {
+	if e == nil {
+		return nil, nil
+	}
+	var result game_session.GameResult
+	if err := json.Unmarshal([]byte(e.Result), &result); err != nil {
+		return nil, err
+	}
+	return &game_session.Archive{
+		SessionID:   e.SessionID,
+		ResultToken: e.ResultToken,
+		Result:      result,
+		WeekData:    json.RawMessage(e.WeekData),
+		ArchivedAt:  e.ArchivedAt.Format(time.RFC3339),
+	}, nil
+}
+
+func ArchiveFromDomain(a *game_session.Archive) (*GameSessionArchiveEntity, error) {
+	if a == nil {
+		return nil, nil
+	}
+	result, err := json.Marshal(a.Result)
+	if err != nil {
+		return nil, err
+	}
+	return &GameSessionArchiveEntity{
+		SessionID:   a.SessionID,
+		ResultToken: a.ResultToken,
+		Result:      string(result),
+		WeekData:    string(a.WeekData),
+		ArchivedAt:  parseTime(a.ArchivedAt),
+	}, nil
+}
@@ -2,18 +2,45 @@ package game_session
 
 import (
 	"backend/domain/game_session"
+	"backend/pkg/money"
+	"sort"
+	"strings"
 	"time"
 )
 
+// GameSessionEntity is a session row. idx_challenge_attempt lets a username
+// play each day's challenge only once, whichever API instance starts it. A
+// challenge session that failed to craft clears its date to leave the index.
 type GameSessionEntity struct {
-	SessionID     string    `gorm:"column:session_id;primaryKey;type:varchar(64)" json:"session_id"`
-	Username      string    `gorm:"column:username;type:varchar(100);not null" json:"username"`
-	Cash          float64   `gorm:"column:cash;type:decimal(15,2);default:10000.00" json:"cash"`
-	HoldingsValue float64   `gorm:"column:holdings_value;type:decimal(15,2);default:0.00" json:"holdings_value"`
-	TotalBalance  float64   `gorm:"column:total_balance;type:decimal(15,2);default:10000.00" json:"total_balance"`
-	Status        string    `gorm:"column:status;type:varchar(20);default:'starting'" json:"status"`
-	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
-	UpdatedAt     time.Time `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+	SessionID         string      `gorm:"column:session_id;primaryKey;type:varchar(64)" json:"session_id"`
+	Username          string      `gorm:"column:username;type:varchar(100);not null;uniqueIndex:idx_challenge_attempt,priority:2,where:mode = 'challenge' AND challenge_date <> ''" json:"username"`
+	Cash              money.Money `gorm:"column:cash;type:decimal(15,2);default:10000.00" json:"cash"`
+	HoldingsValue     money.Money `gorm:"column:holdings_value;type:decimal(15,2);default:0.00" json:"holdings_value"`
+	TotalBalance      money.Money `gorm:"column:total_balance;type:decimal(15,2);default:10000.00" json:"total_balance"`
+	FeesPaid          money.Money `gorm:"column:fees_paid;type:decimal(15,2);default:0.00" json:"fees_paid"`
+	Weeks             int         `gorm:"column:weeks;default:5" json:"weeks"`
+	StartingCash      money.Money `gorm:"column:starting_cash;type:decimal(15,2);default:10000.00" json:"starting_cash"`
+	StocksPerCategory int         `gorm:"column:stocks_per_category;default:4" json:"stocks_per_category"`
+	HeadlinesPerWeek  int         `gorm:"column:headlines_per_week;default:3" json:"headlines_per_week"`
+	Difficulty        string      `gorm:"column:difficulty;type:varchar(10);default:'normal';index" json:"difficulty"`
+	Categories        string      `gorm:"column:categories;type:varchar(255);default:''" json:"categories"`
+	Mode              string      `gorm:"column:mode;type:varchar(16);default:'classic'" json:"mode"`
+	ChallengeDate     string      `gorm:"column:challenge_date;type:varchar(10);default:'';index;uniqueIndex:idx_challenge_attempt,priority:1,where:mode = 'challenge' AND challenge_date <> ''" json:"challenge_date"`
+	RoomCode          string      `gorm:"column:room_code;type:varchar(8);default:'';index" json:"room_code"`
+	Return            float64     `gorm:"column:player_return;default:0" json:"return"`
+	BenchmarkReturn   float64     `gorm:"column:benchmark_return;default:0" json:"benchmark_return"`
+	Alpha             float64     `gorm:"column:alpha;default:0" json:"alpha"`
+	Efficiency        float64     `gorm:"column:efficiency;default:0" json:"efficiency"`
+	MaxDrawdown       float64     `gorm:"column:max_drawdown;default:0" json:"max_drawdown"`
+	Volatility        float64     `gorm:"column:volatility;default:0" json:"volatility"`
+	SharpeRatio       float64     `gorm:"column:sharpe_ratio;default:0" json:"sharpe_ratio"`
+	DetectionScore    float64     `gorm:"column:detection_score;default:0" json:"detection_score"`
+	Day               int         `gorm:"column:day;default:1" json:"day"`
+	Status            string      `gorm:"column:status;type:varchar(20);default:'starting'" json:"status"`
+	CreatedAt         time.Time   `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time   `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+	FinishedAt        *time.Time  `gorm:"column:finished_at;index" json:"finished_at"`
+	ResultToken       string      `gorm:"column:result_token;type:varchar(64);index" json:"result_token"`
 }
 
 func (GameSessionEntity) TableName() string {
@@ -30,9 +57,32 @@ func ToDomain(e *GameSessionEntity) *game_session.GameSession {
 		Cash:          e.Cash,
 		HoldingsValue: e.HoldingsValue,
 		TotalBalance:  e.TotalBalance,
-		Status:        game_session.GameSessionStatus(e.Status),
-		CreatedAt:     e.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:     e.UpdatedAt.Format(time.RFC3339),
+		FeesPaid:      e.FeesPaid,
+		Rules: game_session.GameRules{
+			Weeks:             e.Weeks,
+			StartingCash:      e.StartingCash,
+			StocksPerCategory: e.StocksPerCategory,
+			HeadlinesPerWeek:  e.HeadlinesPerWeek,
+			Difficulty:        game_session.Difficulty(e.Difficulty),
+		}.WithDefaults(),
+		Return:          e.Return,
+		BenchmarkReturn: e.BenchmarkReturn,
+		Alpha:           e.Alpha,
+		Efficiency:      e.Efficiency,
+		MaxDrawdown:     e.MaxDrawdown,
+		Volatility:      e.Volatility,
+		SharpeRatio:     e.SharpeRatio,
+		DetectionScore:  e.DetectionScore,
+		Status:          game_session.GameSessionStatus(e.Status),
+		Day:             e.Day,
+		CreatedAt:       e.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:       e.UpdatedAt.Format(time.RFC3339),
+		FinishedAt:      formatOptionalTime(e.FinishedAt),
+		Categories:      splitCategories(e.Categories),
+		Mode:            game_session.GameMode(e.Mode),
+		ChallengeDate:   e.ChallengeDate,
+		RoomCode:        e.RoomCode,
+		ResultToken:     e.ResultToken,
 	}
 }
 
@@ -41,17 +91,67 @@ func FromDomain(s *game_session.GameSession) *GameSessionEntity {
 		return nil
 	}
 	return &GameSessionEntity{
-		SessionID:     s.SessionID,
-		Username:      s.Username,
-		Cash:          s.Cash,
-		HoldingsValue: s.HoldingsValue,
-		TotalBalance:  s.TotalBalance,
-		Status:        s.Status.String(),
-		CreatedAt:     parseTime(s.CreatedAt),
-		UpdatedAt:     parseTime(s.UpdatedAt),
+		SessionID:         s.SessionID,
+		Username:          s.Username,
+		Cash:              s.Cash,
+		HoldingsValue:     s.HoldingsValue,
+		TotalBalance:      s.TotalBalance,
+		FeesPaid:          s.FeesPaid,
+		Weeks:             s.Rules.Weeks,
+		StartingCash:      s.Rules.StartingCash,
+		StocksPerCategory: s.Rules.StocksPerCategory,
+		HeadlinesPerWeek:  s.Rules.HeadlinesPerWeek,
+		Difficulty:        string(s.Rules.Difficulty),
+		Return:            s.Return,
+		BenchmarkReturn:   s.BenchmarkReturn,
+		Alpha:             s.Alpha,
+		Efficiency:        s.Efficiency,
+		MaxDrawdown:       s.MaxDrawdown,
+		Volatility:        s.Volatility,
+		SharpeRatio:       s.SharpeRatio,
+		DetectionScore:    s.DetectionScore,
+		Status:            s.Status.String(),
+		Day:               s.Day,
+		CreatedAt:         parseTime(s.CreatedAt),
+		UpdatedAt:         parseTime(s.UpdatedAt),
+		FinishedAt:        parseOptionalTime(s.FinishedAt),
+		Categories:        JoinCategories(s.Categories),
+		Mode:              string(s.Mode),
+		ChallengeDate:     s.ChallengeDate,
+		RoomCode:          s.RoomCode,
+		ResultToken:       s.ResultToken,
 	}
 }
 
+// JoinCategories stores a category set as a sorted, comma separated list.
+func JoinCategories(categories []string) string {
+	sorted := append([]string(nil), categories...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+func splitCategories(categories string) []string {
+	if categories == "" {
+		return []string{}
+	}
+	return strings.Split(categories, ",")
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func parseOptionalTime(timeStr string) *time.Time {
+	if timeStr == "" {
+		return nil
+	}
+	t := parseTime(timeStr)
+	return &t
+}
+
 func parseTime(timeStr string) time.Time {
 	t, err := time.Parse(time.RFC3339, timeStr)
 	if err != nil {
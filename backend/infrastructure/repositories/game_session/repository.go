@@ -28,6 +28,9 @@ func NewRepository(db *gorm.DB, redisService redis.RedisService) game_session.Re
 func (r *repository) Save(session *game_session.GameSession) error {
 	entity := FromDomain(session)
 	if err := r.db.Create(entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) && session.Mode == game_session.ModeChallenge {
+			return errors.New(errors.ErrConflict, fmt.Sprintf("%s has already played the %s challenge", session.Username, session.ChallengeDate))
+		}
 		return errors.Wrap(errors.ErrInternal, "failed to save session", err)
 	}
 	redisKey := fmt.Sprintf("session:%s:metadata", session.SessionID)
@@ -70,16 +73,84 @@ func (r *repository) FindBySessionID(sessionID string) (*game_session.GameSessio
 	return session, nil
 }
 
-func (r *repository) FindLeaderboardTop10(page, pageSize int) ([]game_session.GameSession, error) {
-	var entities []GameSessionEntity
-	offset := (page - 1) * pageSize
+// leaderboardColumns maps each metric to the expression sessions are ranked by.
+// Sessions start with different cash depending on their difficulty, so the
+// return is computed from the balances rather than ranking by cash.
+var leaderboardColumns = map[game_session.LeaderboardMetric]string{
+	game_session.MetricReturn:      "cash / starting_cash",
+	game_session.MetricAlpha:       "alpha",
+	game_session.MetricEfficiency:  "efficiency",
+	game_session.MetricSharpe:      "sharpe_ratio",
+	game_session.MetricMaxDrawdown: "max_drawdown",
+	game_session.MetricVolatility:  "volatility",
+}
 
-	if err := r.db.Where("status = ?", game_session.StatusFinished).
-		Order("cash DESC").
+func leaderboardOrder(metric game_session.LeaderboardMetric) string {
+	column, ok := leaderboardColumns[metric]
+	if !ok {
+		column = leaderboardColumns[game_session.MetricReturn]
+	}
+	// Ties go to the session that finished first.
+	if metric.LowerIsBetter() {
+		return column + " ASC, cash / starting_cash DESC, finished_at ASC, session_id ASC"
+	}
+	return column + " DESC, finished_at ASC, session_id ASC"
+}
+
+func (r *repository) FindLeaderboard(query game_session.LeaderboardQuery) ([]game_session.GameSession, int64, error) {
+	db := r.db.Model(&GameSessionEntity{}).Where("status = ?", game_session.StatusFinished)
+
+	if since := query.Window.Since(time.Now()); !since.IsZero() {
+		db = db.Where("finished_at >= ?", since)
+	}
+	if query.Difficulty != "" {
+		db = db.Where("difficulty = ?", query.Difficulty)
+	}
+	if query.ChallengeDate != "" {
+		db = db.Where("mode = ? AND challenge_date = ?", game_session.ModeChallenge, query.ChallengeDate)
+	}
+	for _, category := range query.Categories {
+		// Categories are stored comma separated, so the surrounding commas
+		// keep one category from matching another that contains it.
+		db = db.Where("',' || categories || ',' LIKE ?", "%,"+category+",%")
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(errors.ErrInternal, "failed to count leaderboard entries", err)
+	}
+
+	var entities []GameSessionEntity
+	offset := (query.Page - 1) * query.PageSize
+	if err := db.Order(leaderboardOrder(query.Metric)).
 		Offset(offset).
-		Limit(pageSize).
+		Limit(query.PageSize).
 		Find(&entities).Error; err != nil {
-		return nil, err
+		return nil, 0, errors.Wrap(errors.ErrInternal, "failed to find leaderboard entries", err)
+	}
+
+	sessions := make([]game_session.GameSession, len(entities))
+	for i, entity := range entities {
+		sessions[i] = *ToDomain(&entity)
+	}
+	return sessions, total, nil
+}
+
+func (r *repository) SetCategories(sessionID string, categories []string) error {
+	if err := r.db.Model(&GameSessionEntity{}).
+		Where("session_id = ?", sessionID).
+		Update("categories", JoinCategories(categories)).Error; err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to update session categories", err)
+	}
+	return nil
+}
+
+func (r *repository) FindByRoomCode(code string) ([]game_session.GameSession, error) {
+	var entities []GameSessionEntity
+	if err := r.db.Where("mode = ? AND room_code = ?", game_session.ModeRoom, code).
+		Order("total_balance DESC, session_id ASC").
+		Find(&entities).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to find room sessions", err)
 	}
 
 	sessions := make([]game_session.GameSession, len(entities))
@@ -89,6 +160,37 @@ func (r *repository) FindLeaderboardTop10(page, pageSize int) ([]game_session.Ga
 	return sessions, nil
 }
 
+func (r *repository) ExpiresAt(sessionID string) (time.Time, error) {
+	redisKey := fmt.Sprintf("session:%s:metadata", sessionID)
+	ttl, err := r.redisService.TTL(context.Background(), redisKey)
+	if err != nil || ttl == 0 {
+		return time.Time{}, err
+	}
+	return time.Now().Add(ttl), nil
+}
+
+func (r *repository) Touch(sessionID string) error {
+	redisKey := fmt.Sprintf("session:%s:metadata", sessionID)
+	return r.redisService.Expire(context.Background(), redisKey, 2*time.Hour)
+}
+
+func (r *repository) SaveStreamTicket(ticket string, sessionID string, ttl time.Duration) error {
+	redisKey := fmt.Sprintf("stream-ticket:%s", ticket)
+	return r.redisService.Set(context.Background(), redisKey, sessionID, ttl)
+}
+
+func (r *repository) TakeStreamTicket(ticket string) (string, error) {
+	var sessionID string
+	redisKey := fmt.Sprintf("stream-ticket:%s", ticket)
+	if err := r.redisService.GetDel(context.Background(), redisKey, &sessionID); err != nil {
+		if errors.GetCode(err) == errors.ErrNotFound {
+			return "", errors.New(errors.ErrUnauthorized, "stream ticket is invalid, expired or already used")
+		}
+		return "", err
+	}
+	return sessionID, nil
+}
+
 func (r *repository) BeginTransaction(sessionID string) (game_session.GameSessionTx, error) {
 	// Begin a database transaction
 	tx := r.db.Begin()
@@ -146,3 +248,113 @@ func (r *repository) UpdateGameCraftingStatus(sessionID string, success bool) er
 
 	return nil
 }
+
+func (r *repository) ReleaseChallengeAttempt(sessionID string) error {
+	// Without its date the session falls out of idx_challenge_attempt.
+	if err := r.db.Model(&GameSessionEntity{}).
+		Where("session_id = ? AND mode = ?", sessionID, game_session.ModeChallenge).
+		Update("challenge_date", "").Error; err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to release challenge attempt", err)
+	}
+	return nil
+}
+
+func (r *repository) FindTradesBySessionID(sessionID string) ([]game_session.Trade, error) {
+	var count int64
+	if err := r.db.Model(&GameSessionEntity{}).Where("session_id = ?", sessionID).Count(&count).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to find session", err)
+	}
+	if count == 0 {
+		return nil, errors.New(errors.ErrNotFound, "session not found")
+	}
+
+	var entities []GameTradeEntity
+	if err := r.db.Where("session_id = ?", sessionID).
+		Order("created_at ASC, id ASC").
+		Find(&entities).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to find trades", err)
+	}
+
+	trades := make([]game_session.Trade, len(entities))
+	for i, entity := range entities {
+		trades[i] = *TradeToDomain(&entity)
+	}
+	return trades, nil
+}
+
+func (r *repository) FindSnapshotsBySessionID(sessionID string) ([]game_session.Snapshot, error) {
+	var count int64
+	if err := r.db.Model(&GameSessionEntity{}).Where("session_id = ?", sessionID).Count(&count).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to find session", err)
+	}
+	if count == 0 {
+		return nil, errors.New(errors.ErrNotFound, "session not found")
+	}
+
+	var entities []GameSessionSnapshotEntity
+	if err := r.db.Where("session_id = ?", sessionID).
+		Order("week ASC").
+		Find(&entities).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to find snapshots", err)
+	}
+
+	snapshots := make([]game_session.Snapshot, len(entities))
+	for i, entity := range entities {
+		snapshots[i] = *SnapshotToDomain(&entity)
+	}
+	return snapshots, nil
+}
+
+func (r *repository) FindRankByResultToken(token string, metric game_session.LeaderboardMetric) (int, int64, error) {
+	var positions []int
+	if err := r.db.Raw(
+		"SELECT position FROM (SELECT result_token, ROW_NUMBER() OVER (ORDER BY "+leaderboardOrder(metric)+") AS position "+
+			"FROM game_sessions WHERE status = ?) AS ranked WHERE result_token = ?",
+		game_session.StatusFinished, token,
+	).Scan(&positions).Error; err != nil {
+		return 0, 0, errors.Wrap(errors.ErrInternal, "failed to rank session", err)
+	}
+	if len(positions) == 0 {
+		return 0, 0, errors.New(errors.ErrNotFound, "result not found")
+	}
+
+	var total int64
+	if err := r.db.Model(&GameSessionEntity{}).Where("status = ?", game_session.StatusFinished).Count(&total).Error; err != nil {
+		return 0, 0, errors.Wrap(errors.ErrInternal, "failed to count leaderboard entries", err)
+	}
+
+	return positions[0], total, nil
+}
+
+func (r *repository) FindLeaderboardRange(metric game_session.LeaderboardMetric, offset, limit int) ([]game_session.GameSession, error) {
+	var entities []GameSessionEntity
+	if err := r.db.Where("status = ?", game_session.StatusFinished).
+		Order(leaderboardOrder(metric)).
+		Offset(offset).
+		Limit(limit).
+		Find(&entities).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to find leaderboard entries", err)
+	}
+
+	sessions := make([]game_session.GameSession, len(entities))
+	for i, entity := range entities {
+		sessions[i] = *ToDomain(&entity)
+	}
+	return sessions, nil
+}
+
+func (r *repository) FindArchiveByResultToken(token string) (*game_session.Archive, error) {
+	var entity GameSessionArchiveEntity
+	if err := r.db.Where("result_token = ?", token).First(&entity).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New(errors.ErrNotFound, "results not found")
+		}
+		return nil, errors.Wrap(errors.ErrInternal, "failed to find results", err)
+	}
+
+	archive, err := ArchiveToDomain(&entity)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to decode results", err)
+	}
+	return archive, nil
+}
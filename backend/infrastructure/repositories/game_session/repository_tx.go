@@ -40,6 +40,38 @@ func (tx *gameSessionTx) Update(session *game_session.GameSession) error {
 	return nil
 }
 
+func (tx *gameSessionTx) RecordTrade(trade *game_session.Trade) error {
+	entity := TradeFromDomain(trade)
+	if err := tx.tx.Create(entity).Error; err != nil {
+		return fmt.Errorf("failed to record trade: %w", err)
+	}
+
+	trade.ID = TradeToDomain(entity).ID
+	return nil
+}
+
+func (tx *gameSessionTx) RecordSnapshot(snapshot *game_session.Snapshot) error {
+	entity, err := SnapshotFromDomain(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := tx.tx.Create(entity).Error; err != nil {
+		return fmt.Errorf("failed to record snapshot: %w", err)
+	}
+	return nil
+}
+
+func (tx *gameSessionTx) Archive(archive *game_session.Archive) error {
+	entity, err := ArchiveFromDomain(archive)
+	if err != nil {
+		return fmt.Errorf("failed to encode archive: %w", err)
+	}
+	if err := tx.tx.Create(entity).Error; err != nil {
+		return fmt.Errorf("failed to archive session: %w", err)
+	}
+	return nil
+}
+
 func (tx *gameSessionTx) Commit() error {
 	return tx.tx.Commit().Error
 }
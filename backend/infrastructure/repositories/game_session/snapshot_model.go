@@ -0,0 +1,64 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/pkg/money"
+	"encoding/json"
+	"time"
+)
+
+type GameSessionSnapshotEntity struct {
+	SessionID     string      `gorm:"column:session_id;primaryKey;type:varchar(64)" json:"session_id"`
+	Week          int         `gorm:"column:week;primaryKey" json:"week"`
+	Cash          money.Money `gorm:"column:cash;type:decimal(15,2);not null" json:"cash"`
+	HoldingsValue money.Money `gorm:"column:holdings_value;type:decimal(15,2);not null" json:"holdings_value"`
+	TotalBalance  money.Money `gorm:"column:total_balance;type:decimal(15,2);not null" json:"total_balance"`
+	Positions     string      `gorm:"column:positions;type:jsonb" json:"positions"`
+	CreatedAt     time.Time   `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+}
+
+func (GameSessionSnapshotEntity) TableName() string {
+	return "game_session_snapshots"
+}
+
+func SnapshotToDomain(e *GameSessionSnapshotEntity) *game_session.Snapshot {
+	if e == nil {
+		return nil
+	}
+	positions := []game_session.SnapshotPosition{}
+	if e.Positions != "" {
+		_ = json.Unmarshal([]byte(e.Positions), &positions)
+	}
+	return &game_session.Snapshot{
+		SessionID:     e.SessionID,
+		Week:          e.Week,
+		Cash:          e.Cash,
+		HoldingsValue: e.HoldingsValue,
+		TotalBalance:  e.TotalBalance,
+		Positions:     positions,
+		CreatedAt:     e.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func SnapshotFromDomain(s *game_session.Snapshot) (*GameSessionSnapshotEntity, error) {
+	if s == nil {
+		return nil, nil
+	}
+	positions := s.Positions
+	if positions == nil {
+		positions = []game_session.SnapshotPosition{}
+	}
+	encoded, err := json.Marshal(positions)
+	if err != nil {
+		return nil, err
+	}
+	return &GameSessionSnapshotEntity{
+		SessionID:     s.SessionID,
+		Week:          s.Week,
+		Cash:          s.Cash,
+		HoldingsValue: s.HoldingsValue,
+		TotalBalance:  s.TotalBalance,
+		Positions:     string(encoded),
+		CreatedAt:     parseTime(s.CreatedAt),
+	}, nil
+}
@@ -0,0 +1,74 @@
+package game_session
+
+import (
+	"backend/domain/game_session"
+	"backend/pkg/money"
+	"strconv"
+	"time"
+)
+
+type GameTradeEntity struct {
+	ID         uint        `gorm:"column:id;primaryKey" json:"id"`
+	SessionID  string      `gorm:"column:session_id;type:varchar(64);not null;index" json:"session_id"`
+	Week       int         `gorm:"column:week;not null" json:"week"`
+	Day        int         `gorm:"column:day;default:1" json:"day"`
+	Ticker     string      `gorm:"column:ticker;type:varchar(10);not null" json:"ticker"`
+	Side       string      `gorm:"column:side;type:varchar(10);not null" json:"side"`
+	Quantity   int         `gorm:"column:quantity;not null" json:"quantity"`
+	Price      money.Money `gorm:"column:price;type:decimal(15,2);not null" json:"price"`
+	Fee        money.Money `gorm:"column:fee;type:decimal(15,2);default:0.00" json:"fee"`
+	CashBefore money.Money `gorm:"column:cash_before;type:decimal(15,2);not null" json:"cash_before"`
+	CashAfter  money.Money `gorm:"column:cash_after;type:decimal(15,2);not null" json:"cash_after"`
+	Source     string      `gorm:"column:source;type:varchar(20);default:'manual'" json:"source"`
+	CreatedAt  time.Time   `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+}
+
+func (GameTradeEntity) TableName() string {
+	return "game_trades"
+}
+
+func TradeToDomain(e *GameTradeEntity) *game_session.Trade {
+	if e == nil {
+		return nil
+	}
+	return &game_session.Trade{
+		ID:         strconv.FormatUint(uint64(e.ID), 10),
+		SessionID:  e.SessionID,
+		Week:       e.Week,
+		Day:        e.Day,
+		Ticker:     e.Ticker,
+		Side:       game_session.OrderSide(e.Side),
+		Quantity:   e.Quantity,
+		Price:      e.Price,
+		Fee:        e.Fee,
+		CashBefore: e.CashBefore,
+		CashAfter:  e.CashAfter,
+		Source:     game_session.TradeSource(e.Source),
+		CreatedAt:  e.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func TradeFromDomain(t *game_session.Trade) *GameTradeEntity {
+	if t == nil {
+		return nil
+	}
+	id, err := strconv.ParseUint(t.ID, 10, 64)
+	if err != nil {
+		id = 0 // For create operations
+	}
+	return &GameTradeEntity{
+		ID:         uint(id),
+		SessionID:  t.SessionID,
+		Week:       t.Week,
+		Day:        t.Day,
+		Ticker:     t.Ticker,
+		Side:       string(t.Side),
+		Quantity:   t.Quantity,
+		Price:      t.Price,
+		Fee:        t.Fee,
+		CashBefore: t.CashBefore,
+		CashAfter:  t.CashAfter,
+		Source:     string(t.Source),
+		CreatedAt:  parseTime(t.CreatedAt),
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"time"
 
+	"backend/domain/game_session"
 	"backend/domain/gm_session"
 	"backend/infrastructure/redis"
+	"backend/pkg/errors"
 )
 
 type repository struct {
@@ -34,13 +36,34 @@ func (r *repository) GetWeekData(sessionID string, week int) (*gm_session.GMWeek
 	return &data, nil
 }
 
+func (r *repository) SaveFakeHeadlines(sessionID string, week int, fakes []int) error {
+	key := fmt.Sprintf("gm:session:%s:week:%d:fakes", sessionID, week)
+	return r.redisService.Set(context.Background(), key, fakes, 2*time.Hour)
+}
+
+func (r *repository) GetFakeHeadlines(sessionID string, week int) ([]int, error) {
+	key := fmt.Sprintf("gm:session:%s:week:%d:fakes", sessionID, week)
+	var fakes []int
+	if err := r.redisService.Get(context.Background(), key, &fakes); err != nil {
+		// Sessions crafted before fake headlines were stored have none.
+		if errors.GetCode(err) == errors.ErrNotFound {
+			return []int{}, nil
+		}
+		return nil, fmt.Errorf("failed to get fake headlines: %w", err)
+	}
+	return fakes, nil
+}
+
 func (r *repository) ClearSessionData(sessionID string) error {
 	ctx := context.Background()
-	for week := 1; week <= 5; week++ {
+	for week := 1; week <= game_session.MaxWeeks; week++ {
 		key := fmt.Sprintf("gm:session:%s:week:%d", sessionID, week)
 		if err := r.redisService.Delete(ctx, key); err != nil {
 			continue
 		}
+		if err := r.redisService.Delete(ctx, key+":fakes"); err != nil {
+			continue
+		}
 	}
 	return nil
 }
@@ -0,0 +1,103 @@
+package room
+
+import (
+	"backend/domain/game_session"
+	"backend/domain/gm_session"
+	"backend/domain/room"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+type RoomEntity struct {
+	Code          string    `gorm:"column:code;primaryKey;type:varchar(8)" json:"code"`
+	HostSessionID string    `gorm:"column:host_session_id;type:varchar(64);not null" json:"host_session_id"`
+	Status        string    `gorm:"column:status;type:varchar(16);not null" json:"status"`
+	Week          int       `gorm:"column:week;default:1" json:"week"`
+	Categories    string    `gorm:"column:categories;type:varchar(255);default:''" json:"categories"`
+	Rules         string    `gorm:"column:rules;type:jsonb;not null" json:"rules"`
+	WeekData      string    `gorm:"column:week_data;type:jsonb;not null" json:"week_data"`
+	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+}
+
+func (RoomEntity) TableName() string {
+	return "rooms"
+}
+
+type RoomMemberEntity struct {
+	RoomCode  string    `gorm:"column:room_code;primaryKey;type:varchar(8)" json:"room_code"`
+	SessionID string    `gorm:"column:session_id;primaryKey;type:varchar(64)" json:"session_id"`
+	Username  string    `gorm:"column:username;type:varchar(100);not null" json:"username"`
+	Host      bool      `gorm:"column:host;default:false" json:"host"`
+	Ready     bool      `gorm:"column:ready;default:false" json:"ready"`
+	JoinedAt  time.Time `gorm:"column:joined_at;autoCreateTime" json:"joined_at"`
+}
+
+func (RoomMemberEntity) TableName() string {
+	return "room_members"
+}
+
+func ToDomain(e *RoomEntity, members []RoomMemberEntity) (*room.Room, error) {
+	if e == nil {
+		return nil, nil
+	}
+	var rules game_session.GameRules
+	if err := json.Unmarshal([]byte(e.Rules), &rules); err != nil {
+		return nil, err
+	}
+	var weekData map[string]*gm_session.GMWeekData
+	if err := json.Unmarshal([]byte(e.WeekData), &weekData); err != nil {
+		return nil, err
+	}
+
+	var categories []string
+	if e.Categories != "" {
+		categories = strings.Split(e.Categories, ",")
+	}
+
+	domainMembers := make([]room.Member, len(members))
+	for i, member := range members {
+		domainMembers[i] = room.Member{
+			SessionID: member.SessionID,
+			Username:  member.Username,
+			Host:      member.Host,
+			Ready:     member.Ready,
+		}
+	}
+
+	return &room.Room{
+		Code:          e.Code,
+		HostSessionID: e.HostSessionID,
+		Status:        room.Status(e.Status),
+		Week:          e.Week,
+		Rules:         rules,
+		Categories:    categories,
+		Members:       domainMembers,
+		WeekData:      weekData,
+		CreatedAt:     e.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+func FromDomain(r *room.Room) (*RoomEntity, error) {
+	if r == nil {
+		return nil, nil
+	}
+	rules, err := json.Marshal(r.Rules)
+	if err != nil {
+		return nil, err
+	}
+	weekData, err := json.Marshal(r.WeekData)
+	if err != nil {
+		return nil, err
+	}
+	return &RoomEntity{
+		Code:          r.Code,
+		HostSessionID: r.HostSessionID,
+		Status:        string(r.Status),
+		Week:          r.Week,
+		Categories:    strings.Join(r.Categories, ","),
+		Rules:         string(rules),
+		WeekData:      string(weekData),
+	}, nil
+}
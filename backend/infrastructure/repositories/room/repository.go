@@ -0,0 +1,102 @@
+package room
+
+import (
+	"backend/domain/room"
+	"backend/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) room.Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(rm *room.Room) error {
+	entity, err := FromDomain(rm)
+	if err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to encode room", err)
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(entity).Error; err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to save room", err)
+		}
+		for _, member := range rm.Members {
+			if err := tx.Create(memberEntity(rm.Code, member)).Error; err != nil {
+				return errors.Wrap(errors.ErrInternal, "failed to save room member", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *repository) FindByCode(code string) (*room.Room, error) {
+	return findByCode(r.db, code)
+}
+
+func (r *repository) FindCodeBySessionID(sessionID string) (string, error) {
+	var member RoomMemberEntity
+	if err := r.db.Where("session_id = ?", sessionID).First(&member).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", errors.New(errors.ErrNotFound, "the session is not playing in a room")
+		}
+		return "", errors.Wrap(errors.ErrInternal, "failed to find room member", err)
+	}
+	return member.RoomCode, nil
+}
+
+func (r *repository) BeginTransaction(code string) (room.RoomTx, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to begin transaction", tx.Error)
+	}
+
+	// Lock the room row so the members read below stay current until commit.
+	rm, err := findByCode(tx.Clauses(clause.Locking{Strength: "UPDATE"}), code)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return &roomTx{tx: tx, room: rm}, nil
+}
+
+// findByCode loads the room and its members. When db locks rows, only the
+// room row is locked, as joining members insert rows of their own.
+func findByCode(db *gorm.DB, code string) (*room.Room, error) {
+	var entity RoomEntity
+	if err := db.Where("code = ?", code).First(&entity).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New(errors.ErrNotFound, "room not found")
+		}
+		return nil, errors.Wrap(errors.ErrInternal, "failed to find room", err)
+	}
+
+	var members []RoomMemberEntity
+	if err := db.Session(&gorm.Session{NewDB: true}).
+		Where("room_code = ?", code).
+		Order("joined_at ASC, session_id ASC").
+		Find(&members).Error; err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to find room members", err)
+	}
+
+	rm, err := ToDomain(&entity, members)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to decode room", err)
+	}
+	return rm, nil
+}
+
+func memberEntity(code string, member room.Member) *RoomMemberEntity {
+	return &RoomMemberEntity{
+		RoomCode:  code,
+		SessionID: member.SessionID,
+		Username:  member.Username,
+		Host:      member.Host,
+		Ready:     member.Ready,
+	}
+}
@@ -0,0 +1,82 @@
+package room
+
+import (
+	"backend/domain/room"
+	"backend/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+type roomTx struct {
+	tx   *gorm.DB
+	room *room.Room
+}
+
+func (tx *roomTx) GetRoom() *room.Room {
+	return tx.room
+}
+
+func (tx *roomTx) Update(rm *room.Room) error {
+	entity, err := FromDomain(rm)
+	if err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to encode room", err)
+	}
+	if err := tx.tx.Model(&RoomEntity{}).
+		Where("code = ?", rm.Code).
+		Updates(map[string]any{
+			"status":     entity.Status,
+			"week":       entity.Week,
+			"categories": entity.Categories,
+			"week_data":  entity.WeekData,
+		}).Error; err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to update room", err)
+	}
+	tx.room = rm
+	return nil
+}
+
+func (tx *roomTx) AddMember(member room.Member) error {
+	if err := tx.tx.Create(memberEntity(tx.room.Code, member)).Error; err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to add room member", err)
+	}
+	tx.room.Members = append(tx.room.Members, member)
+	return nil
+}
+
+func (tx *roomTx) SetReady(sessionID string, ready bool) error {
+	result := tx.tx.Model(&RoomMemberEntity{}).
+		Where("room_code = ? AND session_id = ?", tx.room.Code, sessionID).
+		Update("ready", ready)
+	if result.Error != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to update room member", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, "room member not found")
+	}
+	for i := range tx.room.Members {
+		if tx.room.Members[i].SessionID == sessionID {
+			tx.room.Members[i].Ready = ready
+		}
+	}
+	return nil
+}
+
+func (tx *roomTx) ResetReady() error {
+	if err := tx.tx.Model(&RoomMemberEntity{}).
+		Where("room_code = ?", tx.room.Code).
+		Update("ready", false).Error; err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to reset room members", err)
+	}
+	for i := range tx.room.Members {
+		tx.room.Members[i].Ready = false
+	}
+	return nil
+}
+
+func (tx *roomTx) Commit() error {
+	return tx.tx.Commit().Error
+}
+
+func (tx *roomTx) Rollback() error {
+	return tx.tx.Rollback().Error
+}
@@ -3,6 +3,9 @@ package stock
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
 	"strings"
 
 	"backend/domain/stock"
@@ -83,19 +86,31 @@ func (r *StockRepository) FindPaginated(page int, limit int) ([]stock.Stock, int
 	return stocks, total, nil
 }
 
-func (r *StockRepository) PickStocksForSession(categories []string) ([]stock.Stock, error) {
+func (r *StockRepository) PickStocksForSession(categories []string, perCategory int, seed string) ([]stock.Stock, error) {
 	if len(categories) != 3 {
 		return nil, errors.New(errors.ErrInvalidInput, "exactly 3 categories required")
 	}
+	if perCategory <= 0 {
+		return nil, errors.New(errors.ErrInvalidInput, "at least one stock per category required")
+	}
 
-	result := make([]stock.Stock, 0, 12)
+	result := make([]stock.Stock, 0, perCategory*len(categories))
 
 	for _, category := range categories {
 		var entities []StockEntity
-		err := r.repo.FindRandomByField("category", category, 4, &entities)
+		var err error
+		if seed == "" {
+			err = r.repo.FindRandomByField("category", category, perCategory, &entities)
+		} else {
+			entities, err = r.pickSeeded(category, perCategory, seed)
+		}
 		if err != nil {
 			return nil, errors.Wrap(errors.ErrInternal, "failed to fetch stocks for category", err)
 		}
+		// Every week of the scenario must hold exactly this many stocks.
+		if len(entities) < perCategory {
+			return nil, errors.New(errors.ErrInvalidInput, fmt.Sprintf("category %s has %d stocks, %d needed", category, len(entities), perCategory))
+		}
 
 		for _, entity := range entities {
 			domainStock := ToDomain(&entity)
@@ -106,6 +121,31 @@ func (r *StockRepository) PickStocksForSession(categories []string) ([]stock.Sto
 	return result, nil
 }
 
+// pickSeeded shuffles the category's stocks with a generator seeded by the
+// seed and category, so the same seed always picks the same stocks while the
+// stock list is unchanged.
+func (r *StockRepository) pickSeeded(category string, limit int, seed string) ([]StockEntity, error) {
+	var entities []StockEntity
+	if err := r.repo.FindByField("category", category, &entities); err != nil {
+		return nil, err
+	}
+	sort.Slice(entities, func(i, j int) bool {
+		return entities[i].ID < entities[j].ID
+	})
+
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%s:%s", seed, category)
+	random := rand.New(rand.NewSource(int64(hash.Sum64())))
+	random.Shuffle(len(entities), func(i, j int) {
+		entities[i], entities[j] = entities[j], entities[i]
+	})
+
+	if len(entities) > limit {
+		entities = entities[:limit]
+	}
+	return entities, nil
+}
+
 func (r *StockRepository) applyFilters(query *gorm.DB, filters map[string]string) (*gorm.DB, error) {
 	for field, value := range filters {
 		filterType, exists := stock.ValidFilters[field]
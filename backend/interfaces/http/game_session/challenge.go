@@ -0,0 +1,110 @@
+package game_session
+
+import (
+	"backend/domain/challenge"
+	domain "backend/domain/game_session"
+	"backend/pkg/errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type startChallengeRequest struct {
+	// @Description User's display name
+	// @Required
+	Username string `json:"username" binding:"required" example:"john_doe"`
+}
+
+// @Summary Start the daily challenge
+// @Description Creates a session on today's challenge, the scenario every player of the day plays with the default rules. Today's first session crafts it. Each username gets one attempt a day
+// @Tags Challenge
+// @Accept json
+// @Produce json
+// @Param request body startChallengeRequest true "Challenge session parameters"
+// @Success 201 {object} createSessionResponse "Session created successfully"
+// @Failure 400 {object} errors.Error "Invalid input - Username missing"
+// @Failure 409 {object} errors.Error "The username already played today's challenge"
+// @Failure 500 {object} errors.Error "Internal server error"
+// @Router /challenge/start [post]
+func (h *Handler) StartChallenge(c *gin.Context) {
+	var req startChallengeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(errors.Wrap(errors.ErrInvalidInput, "invalid request body", err))
+		return
+	}
+
+	sessionID, err := h.service.StartChallenge(req.Username)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, createSessionResponse{SessionID: sessionID})
+}
+
+// @Summary Get a daily challenge
+// @Description Retrieves the categories and rules of a day's challenge, once crafted
+// @Tags Challenge
+// @Produce json
+// @Param date query string false "Challenge date (YYYY-MM-DD), today in UTC by default"
+// @Success 200 {object} challenge.DailyChallenge "Daily challenge"
+// @Failure 400 {object} errors.Error "Invalid date"
+// @Failure 404 {object} errors.Error "No challenge for that date"
+// @Failure 500 {object} errors.Error "Internal server error"
+// @Router /challenge [get]
+func (h *Handler) GetChallenge(c *gin.Context) {
+	daily, err := h.service.GetChallenge(challengeDate(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, daily)
+}
+
+// @Summary Get a daily challenge leaderboard
+// @Description Retrieves a page of the finished sessions of a day's challenge ranked by the chosen metric. Drawdown and volatility rank lowest first
+// @Tags Challenge
+// @Produce json
+// @Param date query string false "Challenge date (YYYY-MM-DD), today in UTC by default"
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Entries per page, at most 100" default(10)
+// @Param metric query string false "Ranking metric" Enums(return, alpha, efficiency, sharpe, drawdown, volatility) default(return)
+// @Success 200 {object} game_session.LeaderboardPage "Ranked leaderboard entries with the total count"
+// @Failure 400 {object} errors.Error "Invalid date, page or metric"
+// @Failure 500 {object} errors.Error "Internal server error"
+// @Router /challenge/leaderboard [get]
+func (h *Handler) GetChallengeLeaderboard(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		_ = c.Error(errors.Wrap(errors.ErrInvalidInput, "invalid page", err))
+		return
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+	if err != nil {
+		_ = c.Error(errors.Wrap(errors.ErrInvalidInput, "invalid page size", err))
+		return
+	}
+
+	query := domain.LeaderboardQuery{
+		Page:          page,
+		PageSize:      pageSize,
+		Metric:        domain.LeaderboardMetric(c.DefaultQuery("metric", string(domain.MetricReturn))),
+		Window:        domain.WindowAll,
+		ChallengeDate: challengeDate(c),
+	}
+
+	leaderboard, err := h.service.GetLeaderboard(query)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, leaderboard)
+}
+
+func challengeDate(c *gin.Context) string {
+	return c.DefaultQuery("date", time.Now().UTC().Format(challenge.DateLayout))
+}
@@ -0,0 +1,101 @@
+package game_session
+
+import (
+	"backend/application/game_session"
+	"backend/pkg/errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// eventHeartbeat keeps idle streams from being closed by proxies.
+const eventHeartbeat = 25 * time.Second
+
+type streamTicketResponse struct {
+	// @Description Single-use ticket opening the session's event stream
+	Ticket string `json:"ticket" example:"9f86d081884c7d65"`
+	// @Description Seconds left to open the stream with the ticket
+	ExpiresIn int `json:"expiresIn" example:"30"`
+}
+
+// @Summary Get an event stream ticket
+// @Description Issues a single-use ticket, valid for a few seconds, that opens the session's event stream. Browsers cannot set headers on an EventSource, and the ticket keeps the session token out of URLs
+// @Tags Game Session
+// @Produce json
+// @Security BearerAuth
+// @Success 201 {object} streamTicketResponse "Ticket issued"
+// @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
+// @Failure 404 {object} errors.Error "Session not found"
+// @Failure 503 {object} errors.Error "Session is no longer active"
+// @Router /session/events/ticket [post]
+func (h *Handler) IssueStreamTicket(c *gin.Context) {
+	sessionID := extractBearerToken(c)
+	if sessionID == "" {
+		_ = c.Error(errors.New(errors.ErrUnauthorized, "missing or invalid session token"))
+		return
+	}
+
+	ticket, err := h.service.IssueStreamTicket(sessionID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, streamTicketResponse{
+		Ticket:    ticket,
+		ExpiresIn: int(game_session.StreamTicketTTL.Seconds()),
+	})
+}
+
+// @Summary Stream session events
+// @Description Streams the session's lifecycle as server-sent events: crafting progress, crafting success or failure, week advances, automatic order fills, the end of the session and a warning shortly before it expires. Events sent before the stream opened are not replayed, so read the session state once connected. Browsers, which cannot set headers on an EventSource, pass a ticket from /session/events/ticket instead of the bearer token
+// @Tags Game Session
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param ticket query string false "Single-use stream ticket, when the session token cannot be sent as a bearer token"
+// @Success 200 {object} game_session.Event "Stream of events, named after their type"
+// @Failure 401 {object} errors.Error "Unauthorized - Invalid session or ticket"
+// @Failure 404 {object} errors.Error "Session not found"
+// @Failure 503 {object} errors.Error "Session is no longer active"
+// @Router /session/events [get]
+func (h *Handler) StreamEvents(c *gin.Context) {
+	sessionID := extractBearerToken(c)
+	if sessionID == "" {
+		var err error
+		sessionID, err = h.service.RedeemStreamTicket(c.Query("ticket"))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
+
+	events, err := h.service.StreamEvents(c.Request.Context(), sessionID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	heartbeat := time.NewTicker(eventHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(string(event.Type), event)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		}
+		return true
+	})
+}
@@ -2,9 +2,12 @@ package game_session
 
 import (
 	"backend/application/game_session"
+	domain "backend/domain/game_session"
 	"backend/pkg/errors"
+	"backend/pkg/money"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -29,6 +32,16 @@ type createSessionRequest struct {
 	// @MinItems 3
 	// @MaxItems 3
 	Categories []string `json:"categories" binding:"required,len=3" example:"['tech','healthcare','energy']"`
+	// @Description Number of weeks to play, defaults to 5
+	Weeks int `json:"weeks" example:"5"`
+	// @Description Game difficulty, one of easy, normal, hard or expert, defaults to normal
+	Difficulty string `json:"difficulty" binding:"omitempty,oneof=easy normal hard expert" example:"normal"`
+	// @Description Cash the player starts with, defaults to the difficulty's starting cash
+	StartingCash money.Money `json:"startingCash" example:"10000"`
+	// @Description Stocks drawn from each category, defaults to 4
+	StocksPerCategory int `json:"stocksPerCategory" example:"4"`
+	// @Description Headlines published each week, defaults to 3
+	HeadlinesPerWeek int `json:"headlinesPerWeek" example:"3"`
 }
 
 // @Description Response for session creation
@@ -38,8 +51,8 @@ type createSessionResponse struct {
 }
 
 type updateStateRequest struct {
-	Status string  `json:"status" binding:"required"`
-	Cash   float64 `json:"cash" binding:"required"`
+	Status string      `json:"status" binding:"required"`
+	Cash   money.Money `json:"cash" binding:"required"`
 }
 
 // @Description Request body for trading stocks
@@ -53,6 +66,79 @@ type tradeRequest struct {
 	Quantity int `json:"quantity" binding:"required" example:"100"`
 }
 
+// @Description Request body for quoting a trade
+type quoteRequest struct {
+	// @Description Stock ticker symbol
+	// @Required
+	Ticker string `json:"ticker" binding:"required" example:"AAPL"`
+	// @Description Trade side, either buy or sell
+	// @Required
+	Side string `json:"side" binding:"required,oneof=buy sell" example:"buy"`
+	// @Description Number of shares to trade
+	// @Required
+	// @Minimum 1
+	Quantity int `json:"quantity" binding:"required,min=1" example:"10"`
+}
+
+// @Description A single order of a batch
+type batchOrderItem struct {
+	// @Description Stock ticker symbol
+	// @Required
+	Ticker string `json:"ticker" binding:"required" example:"AAPL"`
+	// @Description Order side, either buy or sell
+	// @Required
+	Side string `json:"side" binding:"required,oneof=buy sell" example:"sell"`
+	// @Description Number of shares to trade
+	// @Required
+	// @Minimum 1
+	Quantity int `json:"quantity" binding:"required,min=1" example:"10"`
+}
+
+// @Description Request body for executing several orders at once
+type batchOrderRequest struct {
+	// @Description Orders to execute, sells are applied before buys
+	// @Required
+	Orders []batchOrderItem `json:"orders" binding:"required,min=1,max=50,dive"`
+}
+
+// @Description Request body for placing a limit order
+type placeOrderRequest struct {
+	// @Description Stock ticker symbol
+	// @Required
+	Ticker string `json:"ticker" binding:"required" example:"AAPL"`
+	// @Description Order side, either buy or sell
+	// @Required
+	Side string `json:"side" binding:"required,oneof=buy sell" example:"buy"`
+	// @Description Number of shares to trade
+	// @Required
+	// @Minimum 1
+	Quantity int `json:"quantity" binding:"required" example:"10"`
+	// @Description Highest price to pay on a buy, lowest price to accept on a sell
+	// @Required
+	LimitPrice money.Money `json:"limitPrice" binding:"required" example:"150.5"`
+	// @Description Last week in which the order may fill, defaults to the final week
+	ExpiryWeek int `json:"expiryWeek" example:"4"`
+}
+
+// @Description Request body for protecting a holding with stop-loss/take-profit thresholds
+type protectionRequest struct {
+	// @Description Stock ticker symbol
+	// @Required
+	Ticker string `json:"ticker" binding:"required" example:"AAPL"`
+	// @Description Sell when the price falls to or below this value, 0 to disable
+	StopLoss money.Money `json:"stopLoss" example:"140"`
+	// @Description Sell when the price rises to or above this value, 0 to disable
+	TakeProfit money.Money `json:"takeProfit" example:"180"`
+	// @Description Shares to sell when triggered, 0 for the whole position
+	Quantity int `json:"quantity" example:"0"`
+}
+
+type flagHeadlineRequest struct {
+	// @Description Position of the headline in the current week, starting at 0
+	// @Required
+	Index *int `json:"index" binding:"required,min=0" example:"2"`
+}
+
 // @Summary Create a new game session
 // @Description Creates a new game session for a user with selected stock categories
 // @Tags Game Session
@@ -60,9 +146,9 @@ type tradeRequest struct {
 // @Produce json
 // @Param request body createSessionRequest true "Session creation parameters"
 // @Success 201 {object} createSessionResponse "Session created successfully"
-// @Failure 400 {object} errors.Error "Invalid input - Username missing or categories != 3"
+// @Failure 400 {object} errors.Error "Invalid input - Username missing, categories != 3 or rules out of range"
 // @Failure 500 {object} errors.Error "Internal server error"
-// @Router /sessions [post]
+// @Router /session/start [post]
 func (h *Handler) CreateSession(c *gin.Context) {
 	var req createSessionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -70,7 +156,15 @@ func (h *Handler) CreateSession(c *gin.Context) {
 		return
 	}
 
-	sessionID, err := h.service.Create(req.Username, req.Categories)
+	rules := domain.GameRules{
+		Weeks:             req.Weeks,
+		StartingCash:      req.StartingCash,
+		StocksPerCategory: req.StocksPerCategory,
+		HeadlinesPerWeek:  req.HeadlinesPerWeek,
+		Difficulty:        domain.Difficulty(req.Difficulty),
+	}
+
+	sessionID, err := h.service.Create(req.Username, req.Categories, rules)
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -88,7 +182,7 @@ func (h *Handler) CreateSession(c *gin.Context) {
 // @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
 // @Failure 404 {object} errors.Error "Session not found"
 // @Failure 503 {object} errors.Error "Session is no longer active"
-// @Router /sessions/state [get]
+// @Router /session/state [get]
 func (h *Handler) GetSessionState(c *gin.Context) {
 	sessionID := extractBearerToken(c)
 	if sessionID == "" {
@@ -107,15 +201,74 @@ func (h *Handler) GetSessionState(c *gin.Context) {
 	c.JSON(http.StatusOK, state)
 }
 
+// @Summary Get portfolio
+// @Description Values every position at the current week's prices with its average cost, unrealized and realized P&L, weight and category
+// @Tags Game Session
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} game_session.Portfolio "Current portfolio"
+// @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
+// @Failure 404 {object} errors.Error "Session not found"
+// @Router /session/portfolio [get]
+func (h *Handler) GetPortfolio(c *gin.Context) {
+	sessionID := extractBearerToken(c)
+	if sessionID == "" {
+		_ = c.Error(errors.New(errors.ErrUnauthorized, "missing or invalid session token"))
+		return
+	}
+
+	portfolio, err := h.service.GetPortfolio(sessionID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, portfolio)
+}
+
 // @Summary Get leaderboard
-// @Description Retrieves top 10 finished sessions ordered by total balance
+// @Description Retrieves a page of finished sessions, with the difficulty they were played on, ranked by the chosen metric. Drawdown and volatility rank lowest first
 // @Tags Game Session
 // @Produce json
-// @Success 200 {array} game_session.GameSession "Leaderboard entries"
+// @Param page query int false "Page number" default(1)
+// @Param pageSize query int false "Entries per page, at most 100" default(10)
+// @Param metric query string false "Ranking metric" Enums(return, alpha, efficiency, sharpe, drawdown, volatility) default(return)
+// @Param window query string false "Only sessions finished in this period" Enums(today, week, month, all) default(all)
+// @Param categories query string false "Comma separated categories the sessions must have been played on"
+// @Param difficulty query string false "Only sessions played on this difficulty" Enums(easy, normal, hard, expert)
+// @Success 200 {object} game_session.LeaderboardPage "Ranked leaderboard entries with the total count"
+// @Failure 400 {object} errors.Error "Invalid page, metric, window or difficulty"
 // @Failure 500 {object} errors.Error "Internal server error"
 // @Router /leaderboard [get]
 func (h *Handler) GetLeaderboard(c *gin.Context) {
-	leaderboard, err := h.service.GetLeaderboard()
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		_ = c.Error(errors.Wrap(errors.ErrInvalidInput, "invalid page", err))
+		return
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+	if err != nil {
+		_ = c.Error(errors.Wrap(errors.ErrInvalidInput, "invalid page size", err))
+		return
+	}
+
+	var categories []string
+	for _, category := range strings.Split(c.Query("categories"), ",") {
+		if category = strings.TrimSpace(category); category != "" {
+			categories = append(categories, category)
+		}
+	}
+
+	query := domain.LeaderboardQuery{
+		Page:       page,
+		PageSize:   pageSize,
+		Metric:     domain.LeaderboardMetric(c.DefaultQuery("metric", string(domain.MetricReturn))),
+		Window:     domain.LeaderboardWindow(c.DefaultQuery("window", string(domain.WindowAll))),
+		Categories: categories,
+		Difficulty: domain.Difficulty(c.Query("difficulty")),
+	}
+
+	leaderboard, err := h.service.GetLeaderboard(query)
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -124,8 +277,31 @@ func (h *Handler) GetLeaderboard(c *gin.Context) {
 	c.JSON(http.StatusOK, leaderboard)
 }
 
+// @Summary Get a session's rank
+// @Description Places a finished session, identified by the result token returned when it ended, on the all-time leaderboard with its percentile and the entries directly above and below it
+// @Tags Game Session
+// @Produce json
+// @Param token query string true "Result token of the finished session"
+// @Param metric query string false "Ranking metric" Enums(return, alpha, efficiency, sharpe, drawdown, volatility) default(return)
+// @Success 200 {object} game_session.RankResult "Rank of the session"
+// @Failure 400 {object} errors.Error "Missing token or invalid metric"
+// @Failure 404 {object} errors.Error "Result not found"
+// @Failure 500 {object} errors.Error "Internal server error"
+// @Router /leaderboard/rank [get]
+func (h *Handler) GetRank(c *gin.Context) {
+	metric := domain.LeaderboardMetric(c.DefaultQuery("metric", string(domain.MetricReturn)))
+
+	rank, err := h.service.GetRank(c.Query("token"), metric)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rank)
+}
+
 // @Summary Buy stocks
-// @Description Purchase a specified quantity of a stock in the current session
+// @Description Purchase a specified quantity of a stock in the current session, or cover an open short position
 // @Tags Trading
 // @Accept json
 // @Produce json
@@ -136,7 +312,7 @@ func (h *Handler) GetLeaderboard(c *gin.Context) {
 // @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
 // @Failure 404 {object} errors.Error "Stock not found"
 // @Failure 422 {object} errors.Error "Insufficient funds"
-// @Router /sessions/buy [post]
+// @Router /session/buy [post]
 func (h *Handler) BuyStock(c *gin.Context) {
 	sessionID := extractBearerToken(c)
 	if sessionID == "" {
@@ -159,17 +335,17 @@ func (h *Handler) BuyStock(c *gin.Context) {
 }
 
 // @Summary Sell stocks
-// @Description Sell a specified quantity of a stock in the current session
+// @Description Sell a specified quantity of a stock in the current session. Selling a stock that is not held opens a short position backed by cash collateral
 // @Tags Trading
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body tradeRequest true "Sell order details"
 // @Success 200 "Sale successful"
-// @Failure 400 {object} errors.Error "Invalid input - Missing ticker or quantity"
+// @Failure 400 {object} errors.Error "Invalid input - Missing ticker or quantity, insufficient holdings or short collateral"
 // @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
-// @Failure 404 {object} errors.Error "Stock not found or insufficient holdings"
-// @Router /sessions/sell [post]
+// @Failure 404 {object} errors.Error "Stock not found"
+// @Router /session/sell [post]
 func (h *Handler) SellStock(c *gin.Context) {
 	sessionID := extractBearerToken(c)
 	if sessionID == "" {
@@ -192,13 +368,14 @@ func (h *Handler) SellStock(c *gin.Context) {
 }
 
 // @Summary Advance to next week
-// @Description Advances the session to the next week, updating stock prices
+// @Description Advances the session to the first trading day of the next week from any day of the current one, applying its dividends and splits to the holdings and updating stock prices
 // @Tags Game Session
 // @Security BearerAuth
 // @Success 200 "Advanced to next week"
 // @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
 // @Failure 400 {object} errors.Error "Cannot advance beyond week 5"
-// @Router /sessions/advance [post]
+// @Failure 409 {object} errors.Error "The session plays in a room, which advances it"
+// @Router /session/advance [post]
 func (h *Handler) AdvanceWeek(c *gin.Context) {
 	sessionID := extractBearerToken(c)
 	if sessionID == "" {
@@ -214,14 +391,39 @@ func (h *Handler) AdvanceWeek(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
+// @Summary Advance to next day
+// @Description Advances the session to the next trading day of the current week, updating stock prices and checking protections and limit orders against them
+// @Tags Game Session
+// @Security BearerAuth
+// @Success 200 "Advanced to next day"
+// @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
+// @Failure 400 {object} errors.Error "Already on the last trading day of the week"
+// @Failure 409 {object} errors.Error "The session plays in a room, which advances it"
+// @Router /session/advance-day [post]
+func (h *Handler) AdvanceDay(c *gin.Context) {
+	sessionID := extractBearerToken(c)
+	if sessionID == "" {
+		_ = c.Error(errors.New(errors.ErrUnauthorized, "missing or invalid session token"))
+		return
+	}
+
+	if err := h.service.AdvanceDay(sessionID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
 // @Summary End session
-// @Description Ends the current session, selling all holdings at current prices
+// @Description Ends the current session, selling all holdings and covering all shorts at current prices, scores the return against an equal-weight buy-and-hold of the stocks in play and compares the ending balance with the best one reachable in hindsight. The hindsight-optimal strategy holds long positions only and pays no fees, as optimal_strategy says, so efficiency is capped at 1
 // @Tags Game Session
 // @Security BearerAuth
-// @Success 202 {object} game_session.GameSession "Session ended successfully"
+// @Success 202 {object} game_session.GameResult "Session ended successfully, with the hindsight-optimal strategy"
 // @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
 // @Failure 400 {object} errors.Error "Can only end session in week 5"
-// @Router /sessions/end [post]
+// @Failure 409 {object} errors.Error "The session plays in a room, which ends it"
+// @Router /session/end [post]
 func (h *Handler) EndSession(c *gin.Context) {
 	sessionID := extractBearerToken(c)
 	if sessionID == "" {
@@ -229,13 +431,306 @@ func (h *Handler) EndSession(c *gin.Context) {
 		return
 	}
 
-	gameSession, err := h.service.EndSession(sessionID)
+	result, err := h.service.EndSession(sessionID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, result)
+}
+
+// @Summary Quote a trade
+// @Description Validates and prices a buy or sell at the current week's price, returning the projected cash, valuation and position without executing it
+// @Tags Trading
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body quoteRequest true "Trade to quote"
+// @Success 200 {object} game_session.TradeQuote "Projected outcome of the trade"
+// @Failure 400 {object} errors.Error "Invalid input - Insufficient funds or shares"
+// @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
+// @Failure 404 {object} errors.Error "Stock not found"
+// @Router /session/quote [post]
+func (h *Handler) QuoteTrade(c *gin.Context) {
+	sessionID := extractBearerToken(c)
+	if sessionID == "" {
+		_ = c.Error(errors.New(errors.ErrUnauthorized, "missing or invalid session token"))
+		return
+	}
+
+	var req quoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(errors.Wrap(errors.ErrInvalidInput, "invalid request body", err))
+		return
+	}
+
+	quote, err := h.service.Quote(sessionID, req.Ticker, domain.OrderSide(req.Side), req.Quantity)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
+
+// @Summary Execute a batch of orders
+// @Description Executes several buys and sells at the current week's prices all-or-nothing. Sells are applied before buys so their proceeds can fund the purchases
+// @Tags Trading
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body batchOrderRequest true "Orders to execute"
+// @Success 200 {array} game_session.Trade "Executed trades"
+// @Failure 400 {object} errors.Error "Invalid input - An order failed, none were applied"
+// @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
+// @Failure 404 {object} errors.Error "Stock not found"
+// @Router /session/orders/batch [post]
+func (h *Handler) ExecuteBatch(c *gin.Context) {
+	sessionID := extractBearerToken(c)
+	if sessionID == "" {
+		_ = c.Error(errors.New(errors.ErrUnauthorized, "missing or invalid session token"))
+		return
+	}
+
+	var req batchOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(errors.Wrap(errors.ErrInvalidInput, "invalid request body", err))
+		return
+	}
+
+	orders := make([]domain.MarketOrder, len(req.Orders))
+	for i, item := range req.Orders {
+		orders[i] = domain.MarketOrder{
+			Ticker:   item.Ticker,
+			Side:     domain.OrderSide(item.Side),
+			Quantity: item.Quantity,
+		}
+	}
+
+	trades, err := h.service.ExecuteBatch(sessionID, orders)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trades)
+}
+
+// @Summary Place a limit order
+// @Description Places a pending buy/sell order that executes on a later trading day once the price reaches the limit. Sell orders close a long position, never open a short: one whose position is gone by the time the limit is reached is cancelled
+// @Tags Trading
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body placeOrderRequest true "Limit order details"
+// @Success 201 {object} game_session.LimitOrder "Order placed"
+// @Failure 400 {object} errors.Error "Invalid input - Bad side, quantity, price or expiry week, or a sell without a long position"
+// @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
+// @Failure 404 {object} errors.Error "Stock not found"
+// @Router /session/orders [post]
+func (h *Handler) PlaceOrder(c *gin.Context) {
+	sessionID := extractBearerToken(c)
+	if sessionID == "" {
+		_ = c.Error(errors.New(errors.ErrUnauthorized, "missing or invalid session token"))
+		return
+	}
+
+	var req placeOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(errors.Wrap(errors.ErrInvalidInput, "invalid request body", err))
+		return
+	}
+
+	order, err := h.service.PlaceOrder(sessionID, req.Ticker, domain.OrderSide(req.Side), req.Quantity, req.LimitPrice, req.ExpiryWeek)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+// @Summary List limit orders
+// @Description Lists the session's limit orders with their current status
+// @Tags Trading
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} game_session.LimitOrder "Limit orders"
+// @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
+// @Failure 503 {object} errors.Error "Session is no longer active"
+// @Router /session/orders [get]
+func (h *Handler) GetOrders(c *gin.Context) {
+	sessionID := extractBearerToken(c)
+	if sessionID == "" {
+		_ = c.Error(errors.New(errors.ErrUnauthorized, "missing or invalid session token"))
+		return
+	}
+
+	orders, err := h.service.GetOrders(sessionID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, orders)
+}
+
+// @Summary Cancel a limit order
+// @Description Cancels an open limit order
+// @Tags Trading
+// @Security BearerAuth
+// @Param id path string true "Order ID"
+// @Success 204 "Order cancelled"
+// @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
+// @Failure 404 {object} errors.Error "Order not found"
+// @Failure 409 {object} errors.Error "Order is no longer open"
+// @Router /session/orders/{id} [delete]
+func (h *Handler) CancelOrder(c *gin.Context) {
+	sessionID := extractBearerToken(c)
+	if sessionID == "" {
+		_ = c.Error(errors.New(errors.ErrUnauthorized, "missing or invalid session token"))
+		return
+	}
+
+	if err := h.service.CancelOrder(sessionID, c.Param("id")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Set stop-loss/take-profit
+// @Description Attaches stop-loss and/or take-profit thresholds to a holding; they are checked when the week advances. Send both as 0 to remove them
+// @Tags Trading
+// @Accept json
+// @Security BearerAuth
+// @Param request body protectionRequest true "Protection thresholds"
+// @Success 200 "Protection updated"
+// @Failure 400 {object} errors.Error "Invalid input - Thresholds on the wrong side of the current price"
+// @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
+// @Failure 404 {object} errors.Error "Holding not found"
+// @Router /session/protection [post]
+func (h *Handler) SetProtection(c *gin.Context) {
+	sessionID := extractBearerToken(c)
+	if sessionID == "" {
+		_ = c.Error(errors.New(errors.ErrUnauthorized, "missing or invalid session token"))
+		return
+	}
+
+	var req protectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(errors.Wrap(errors.ErrInvalidInput, "invalid request body", err))
+		return
+	}
+
+	if err := h.service.SetProtection(sessionID, req.Ticker, req.StopLoss, req.TakeProfit, req.Quantity); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary Flag a fake headline
+// @Description Marks the headline the player thinks is fake this week, replacing any earlier flag. The fake headlines are revealed when the week advances
+// @Tags Trading
+// @Accept json
+// @Security BearerAuth
+// @Param request body flagHeadlineRequest true "Flagged headline"
+// @Success 200 "Headline flagged"
+// @Failure 400 {object} errors.Error "Invalid input - Headline index out of range"
+// @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
+// @Router /session/headlines/flag [post]
+func (h *Handler) FlagHeadline(c *gin.Context) {
+	sessionID := extractBearerToken(c)
+	if sessionID == "" {
+		_ = c.Error(errors.New(errors.ErrUnauthorized, "missing or invalid session token"))
+		return
+	}
+
+	var req flagHeadlineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(errors.Wrap(errors.ErrInvalidInput, "invalid request body", err))
+		return
+	}
+
+	if err := h.service.FlagHeadline(sessionID, *req.Index); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary Get trade history
+// @Description Lists every trade executed in the session, including automatic fills and the final liquidation. Available after the session has finished
+// @Tags Trading
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} game_session.Trade "Trade history"
+// @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
+// @Failure 404 {object} errors.Error "Session not found"
+// @Router /session/trades [get]
+func (h *Handler) GetTrades(c *gin.Context) {
+	sessionID := extractBearerToken(c)
+	if sessionID == "" {
+		_ = c.Error(errors.New(errors.ErrUnauthorized, "missing or invalid session token"))
+		return
+	}
+
+	trades, err := h.service.GetTrades(sessionID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trades)
+}
+
+// @Summary Get equity history
+// @Description Lists the session's cash, holdings value, total balance and positions at the close of every week played. Available after the session has finished
+// @Tags Game Session
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} game_session.Snapshot "Weekly snapshots"
+// @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
+// @Failure 404 {object} errors.Error "Session not found"
+// @Router /session/history [get]
+func (h *Handler) GetHistory(c *gin.Context) {
+	sessionID := extractBearerToken(c)
+	if sessionID == "" {
+		_ = c.Error(errors.New(errors.ErrUnauthorized, "missing or invalid session token"))
+		return
+	}
+
+	history, err := h.service.GetHistory(sessionID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// @Summary Get session results
+// @Description Serves the archived results of a finished session: its final state and scores, the GM data of every week, its trades and weekly history. Results are kept permanently and can be shared through their token
+// @Tags Game Session
+// @Produce json
+// @Param token query string true "Result token returned when the session ended"
+// @Success 200 {object} game_session.SessionResults "Archived session results"
+// @Failure 400 {object} errors.Error "Missing token"
+// @Failure 404 {object} errors.Error "Results not found"
+// @Router /session/results [get]
+func (h *Handler) GetResults(c *gin.Context) {
+	results, err := h.service.GetResults(c.Query("token"))
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusAccepted, gameSession)
+	c.JSON(http.StatusOK, results)
 }
 
 func extractBearerToken(c *gin.Context) string {
@@ -0,0 +1,177 @@
+package game_session
+
+import (
+	domain "backend/domain/game_session"
+	"backend/pkg/errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Description Response for room creation
+type createRoomResponse struct {
+	// @Description The host's session identifier
+	SessionID string `json:"sessionId" example:"abc123def456"`
+	// @Description Code other players join the room with
+	Code string `json:"code" example:"K7PQ2M"`
+}
+
+type joinRoomRequest struct {
+	// @Description User's display name
+	// @Required
+	Username string `json:"username" binding:"required" example:"jane_doe"`
+}
+
+// @Summary Create a room
+// @Description Creates a room hosted by a new session and crafts the scenario every player of the room plays. Other players join with the returned code
+// @Tags Room
+// @Accept json
+// @Produce json
+// @Param request body createSessionRequest true "Room creation parameters, shared by every player"
+// @Success 201 {object} createRoomResponse "Room created successfully"
+// @Failure 400 {object} errors.Error "Invalid input - Username missing, categories != 3 or rules out of range"
+// @Failure 500 {object} errors.Error "Internal server error"
+// @Router /room [post]
+func (h *Handler) CreateRoom(c *gin.Context) {
+	var req createSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(errors.Wrap(errors.ErrInvalidInput, "invalid request body", err))
+		return
+	}
+
+	rules := domain.GameRules{
+		Weeks:             req.Weeks,
+		StartingCash:      req.StartingCash,
+		StocksPerCategory: req.StocksPerCategory,
+		HeadlinesPerWeek:  req.HeadlinesPerWeek,
+		Difficulty:        domain.Difficulty(req.Difficulty),
+	}
+
+	sessionID, code, err := h.service.CreateRoom(req.Username, req.Categories, rules)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, createRoomResponse{SessionID: sessionID, Code: code})
+}
+
+// @Summary Join a room
+// @Description Creates a session playing the room's scenario. Players can join until the room moves past its first week
+// @Tags Room
+// @Accept json
+// @Produce json
+// @Param code path string true "Room code"
+// @Param request body joinRoomRequest true "Player parameters"
+// @Success 201 {object} createSessionResponse "Joined successfully"
+// @Failure 400 {object} errors.Error "Invalid input - Username missing"
+// @Failure 404 {object} errors.Error "Room not found"
+// @Failure 409 {object} errors.Error "Room full or no longer open to new players"
+// @Failure 500 {object} errors.Error "Internal server error"
+// @Router /room/{code}/join [post]
+func (h *Handler) JoinRoom(c *gin.Context) {
+	var req joinRoomRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(errors.Wrap(errors.ErrInvalidInput, "invalid request body", err))
+		return
+	}
+
+	sessionID, err := h.service.JoinRoom(c.Param("code"), req.Username)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, createSessionResponse{SessionID: sessionID})
+}
+
+// @Summary Get a room
+// @Description Retrieves the room's status, week, rules and players with their readiness
+// @Tags Room
+// @Produce json
+// @Param code path string true "Room code"
+// @Success 200 {object} room.Room "Room"
+// @Failure 404 {object} errors.Error "Room not found"
+// @Failure 500 {object} errors.Error "Internal server error"
+// @Router /room/{code} [get]
+func (h *Handler) GetRoom(c *gin.Context) {
+	rm, err := h.service.GetRoom(c.Param("code"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rm)
+}
+
+// @Summary Get a room leaderboard
+// @Description Ranks the room's players by their current balance, or their final one once their session has ended
+// @Tags Room
+// @Produce json
+// @Param code path string true "Room code"
+// @Success 200 {array} room.Standing "Room standings"
+// @Failure 404 {object} errors.Error "Room not found"
+// @Failure 500 {object} errors.Error "Internal server error"
+// @Router /room/{code}/leaderboard [get]
+func (h *Handler) GetRoomLeaderboard(c *gin.Context) {
+	standings, err := h.service.GetRoomLeaderboard(c.Param("code"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, standings)
+}
+
+// @Summary Mark the player ready
+// @Description Marks the session's player ready for the next week. The room advances, or ends in its final week, once every player is ready
+// @Tags Room
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} room.Room "Room after the player got ready"
+// @Failure 400 {object} errors.Error "The session does not play in a room"
+// @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
+// @Failure 409 {object} errors.Error "The room is not playing"
+// @Router /room/ready [post]
+func (h *Handler) SetReady(c *gin.Context) {
+	sessionID := extractBearerToken(c)
+	if sessionID == "" {
+		_ = c.Error(errors.New(errors.ErrUnauthorized, "missing or invalid session token"))
+		return
+	}
+
+	rm, err := h.service.SetReady(sessionID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rm)
+}
+
+// @Summary Advance the room
+// @Description Advances every session of the room to the next week, or ends them all in the final week, without waiting for every player to be ready. Host only
+// @Tags Room
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} room.Room "Room after advancing"
+// @Failure 400 {object} errors.Error "The session does not play in a room"
+// @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
+// @Failure 403 {object} errors.Error "Only the host can advance the room"
+// @Failure 409 {object} errors.Error "The room is not playing"
+// @Router /room/advance [post]
+func (h *Handler) AdvanceRoom(c *gin.Context) {
+	sessionID := extractBearerToken(c)
+	if sessionID == "" {
+		_ = c.Error(errors.New(errors.ErrUnauthorized, "missing or invalid session token"))
+		return
+	}
+
+	rm, err := h.service.AdvanceRoom(sessionID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rm)
+}
@@ -9,11 +9,43 @@ func RegisterRoutes(r *gin.RouterGroup, h *Handler) {
 	{
 		sessions.POST("/start", h.CreateSession)
 		sessions.GET("/state", h.GetSessionState)
+		sessions.GET("/events", h.StreamEvents)
+		sessions.POST("/events/ticket", h.IssueStreamTicket)
+		sessions.GET("/portfolio", h.GetPortfolio)
 		sessions.POST("/buy", h.BuyStock)
 		sessions.POST("/sell", h.SellStock)
+		sessions.POST("/quote", h.QuoteTrade)
 		sessions.POST("/advance", h.AdvanceWeek)
+		sessions.POST("/advance-day", h.AdvanceDay)
 		sessions.POST("/end", h.EndSession)
+		sessions.GET("/orders", h.GetOrders)
+		sessions.POST("/orders", h.PlaceOrder)
+		sessions.POST("/orders/batch", h.ExecuteBatch)
+		sessions.DELETE("/orders/:id", h.CancelOrder)
+		sessions.POST("/protection", h.SetProtection)
+		sessions.POST("/headlines/flag", h.FlagHeadline)
+		sessions.GET("/trades", h.GetTrades)
+		sessions.GET("/history", h.GetHistory)
+		sessions.GET("/results", h.GetResults)
+	}
+
+	challenges := r.Group("/challenge")
+	{
+		challenges.GET("", h.GetChallenge)
+		challenges.POST("/start", h.StartChallenge)
+		challenges.GET("/leaderboard", h.GetChallengeLeaderboard)
+	}
+
+	rooms := r.Group("/room")
+	{
+		rooms.POST("", h.CreateRoom)
+		rooms.POST("/ready", h.SetReady)
+		rooms.POST("/advance", h.AdvanceRoom)
+		rooms.GET("/:code", h.GetRoom)
+		rooms.POST("/:code/join", h.JoinRoom)
+		rooms.GET("/:code/leaderboard", h.GetRoomLeaderboard)
 	}
 
 	r.GET("/leaderboard", h.GetLeaderboard)
+	r.GET("/leaderboard/rank", h.GetRank)
 }
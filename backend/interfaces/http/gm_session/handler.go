@@ -1,7 +1,8 @@
 package gm_session
 
 import (
-	"backend/application/gm_session"
+	"backend/application/game_session"
+	"backend/pkg/errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -9,45 +10,47 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// Handler manages GM session HTTP endpoints
+// Handler manages GM session HTTP endpoints. Week data is served through the
+// game session service, which knows how far the player has got.
 type Handler struct {
-	service gm_session.Service
+	service game_session.Service
 }
 
-func NewHandler(service gm_session.Service) *Handler {
+func NewHandler(service game_session.Service) *Handler {
 	return &Handler{service: service}
 }
 
 // @Summary Get week data
-// @Description Get the game master's data for a specific week
+// @Description Get the game master's data for the current week or an earlier one. Each headline lists the tickers and categories it affects and the direction it suggests; fake headlines are not marked. The current week's daily prices stop at the session's trading day
 // @Tags GM Session
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param week path int true "Week number (1-5)" minimum(1) maximum(5)
-// @Success 200 {object} gm_session.GMWeekData "Week data including stock prices and news"
+// @Param week path int true "Week number, at most the current week" minimum(1)
+// @Success 200 {object} gm_session.GMWeekData "Week data including stock prices and headlines"
 // @Failure 400 {object} errors.Error "Invalid week number"
 // @Failure 401 {object} errors.Error "Unauthorized - Invalid session"
+// @Failure 403 {object} errors.Error "Week not started yet"
 // @Failure 404 {object} errors.Error "Week data not found"
 // @Failure 500 {object} errors.Error "Internal server error"
 // @Router /gm/week/{week} [get]
 func (h *Handler) GetWeekData(c *gin.Context) {
 	sessionID := extractBearerToken(c)
 	if sessionID == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing or invalid session token"})
+		_ = c.Error(errors.New(errors.ErrUnauthorized, "missing or invalid session token"))
 		return
 	}
 
 	weekStr := c.Param("week")
 	week, err := strconv.Atoi(weekStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid week number"})
+		_ = c.Error(errors.Wrap(errors.ErrInvalidInput, "invalid week number", err))
 		return
 	}
 
 	weekData, err := h.service.GetWeekData(sessionID, week)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
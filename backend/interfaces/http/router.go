@@ -11,7 +11,6 @@ import (
 
 	category "backend/application/category"
 	gameSession "backend/application/game_session"
-	gmSession "backend/application/gm_session"
 	stock "backend/application/stock"
 	categoryHttp "backend/interfaces/http/category"
 	gameSessionHttp "backend/interfaces/http/game_session"
@@ -24,20 +23,17 @@ type Router struct {
 	stockService       *stock.StockService
 	categoryService    *category.CategoryService
 	gameSessionService gameSession.Service
-	gmSessionService   gmSession.Service
 }
 
 func NewRouter(
 	stockService *stock.StockService,
 	categoryService *category.CategoryService,
 	gameSessionService gameSession.Service,
-	gmSessionService gmSession.Service,
 ) *Router {
 	return &Router{
 		stockService:       stockService,
 		categoryService:    categoryService,
 		gameSessionService: gameSessionService,
-		gmSessionService:   gmSessionService,
 	}
 }
 
@@ -79,7 +75,7 @@ func (r *Router) SetupRoutes() *gin.Engine {
 	gameSessionHandler := gameSessionHttp.NewHandler(r.gameSessionService)
 	gameSessionHttp.RegisterRoutes(api, gameSessionHandler)
 
-	gmSessionHandler := gmSessionHttp.NewHandler(r.gmSessionService)
+	gmSessionHandler := gmSessionHttp.NewHandler(r.gameSessionService)
 	gmSessionHttp.RegisterRoutes(api, gmSessionHandler)
 
 	// Swagger documentation endpoint
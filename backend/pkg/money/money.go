@@ -0,0 +1,186 @@
+package money
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact amount of dollars stored as integer cents.
+//
+// Rounding rules: every conversion into Money (from floats, decimal strings,
+// rates or ratios) rounds to the nearest cent, with halves rounded away from
+// zero. Arithmetic between Money values is exact.
+type Money int64
+
+const Zero Money = 0
+
+// FromCents builds an amount from a number of cents.
+func FromCents(cents int64) Money {
+	return Money(cents)
+}
+
+// FromFloat converts a dollar amount to Money, rounding to the nearest cent.
+func FromFloat(amount float64) Money {
+	return Money(math.Round(amount * 100))
+}
+
+// Parse reads a decimal dollar amount such as "1234.5" or "-0.005" exactly,
+// rounding to the nearest cent.
+func Parse(s string) (Money, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return 0, fmt.Errorf("invalid money amount: %q", s)
+	}
+	return fromRat(r.Mul(r, big.NewRat(100, 1))), nil
+}
+
+func fromRat(cents *big.Rat) Money {
+	num := new(big.Int).Set(cents.Num())
+	den := cents.Denom()
+
+	negative := num.Sign() < 0
+	num.Abs(num)
+
+	// Round half away from zero: (2*num + den) / (2*den)
+	num.Mul(num, big.NewInt(2))
+	num.Add(num, den)
+	quotient := num.Quo(num, new(big.Int).Mul(den, big.NewInt(2)))
+
+	if negative {
+		quotient.Neg(quotient)
+	}
+	return Money(quotient.Int64())
+}
+
+func (m Money) Cents() int64 {
+	return int64(m)
+}
+
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+func (m Money) IsZero() bool {
+	return m == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m < 0
+}
+
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+// Mul multiplies the amount by a whole quantity, e.g. a share price by a
+// number of shares.
+func (m Money) Mul(quantity int) Money {
+	return m * Money(quantity)
+}
+
+// MulRate multiplies the amount by a fractional rate, rounding to the nearest cent.
+func (m Money) MulRate(rate float64) Money {
+	r := new(big.Rat).SetFloat64(rate)
+	if r == nil {
+		return 0
+	}
+	return fromRat(r.Mul(r, new(big.Rat).SetInt64(int64(m))))
+}
+
+// MulDiv returns m * num / den rounded to the nearest cent. It is used to
+// split an amount pro rata, e.g. the cost basis of part of a position, so that
+// taking num == den always returns the whole amount.
+func (m Money) MulDiv(num, den int64) Money {
+	if den == 0 {
+		return 0
+	}
+	return fromRat(new(big.Rat).SetFrac(
+		new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(num)),
+		big.NewInt(den),
+	))
+}
+
+// Ratio returns m / other as a float, for percentages and returns.
+func (m Money) Ratio(other Money) float64 {
+	if other == 0 {
+		return 0
+	}
+	return float64(m) / float64(other)
+}
+
+// String formats the amount with exactly two decimals, e.g. "-12.05".
+func (m Money) String() string {
+	sign := ""
+	cents := int64(m)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// MarshalJSON encodes the amount as a JSON number with two decimals.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a numeric string.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value stores the amount in a decimal column.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan reads the amount from a decimal column.
+func (m *Money) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case int64:
+		*m = Money(v * 100)
+		return nil
+	case float64:
+		*m = FromFloat(v)
+		return nil
+	case []byte:
+		parsed, err := Parse(string(v))
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	case string:
+		parsed, err := Parse(v)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into money.Money", src)
+	}
+}
@@ -0,0 +1,155 @@
+package money
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Money
+		wantErr bool
+	}{
+		{input: "1234.5", want: 123450},
+		{input: "0", want: 0},
+		{input: " 12.34 ", want: 1234},
+		{input: "0.004", want: 0},
+		{input: "0.005", want: 1},
+		{input: "-0.005", want: -1},
+		{input: "12.345", want: 1235},
+		{input: "-12.345", want: -1235},
+		{input: "1e2", want: 10000},
+		{input: "abc", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Parse(%q) = %v, want an error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %d cents, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestMulDiv(t *testing.T) {
+	tests := []struct {
+		amount   Money
+		num, den int64
+		want     Money
+	}{
+		{amount: 100, num: 1, den: 3, want: 33},
+		{amount: 200, num: 1, den: 3, want: 67},
+		{amount: 5, num: 1, den: 2, want: 3},
+		{amount: -5, num: 1, den: 2, want: -3},
+		{amount: 1001, num: 7, den: 7, want: 1001},
+		{amount: 999, num: 2, den: 3, want: 666},
+		{amount: 100, num: 1, den: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.MulDiv(tt.num, tt.den); got != tt.want {
+			t.Errorf("Money(%d).MulDiv(%d, %d) = %d, want %d", tt.amount, tt.num, tt.den, got, tt.want)
+		}
+	}
+}
+
+func TestMulDivSplitsReconcile(t *testing.T) {
+	// Taking the parts out one after the other, as selling a position in
+	// several lots does, leaves nothing over.
+	total := Money(1000)
+	quantity := int64(7)
+	remaining := total
+	for left := quantity; left > 0; left-- {
+		part := remaining.MulDiv(1, left)
+		remaining -= part
+	}
+	if remaining != 0 {
+		t.Errorf("remaining after splitting %d into %d parts = %d, want 0", total, quantity, remaining)
+	}
+}
+
+func TestMulRate(t *testing.T) {
+	tests := []struct {
+		amount Money
+		rate   float64
+		want   Money
+	}{
+		{amount: 100000, rate: 0.001, want: 100},
+		{amount: 1000, rate: 0.001, want: 1},
+		{amount: 12345, rate: 0.5, want: 6173},
+		{amount: -12345, rate: 0.5, want: -6173},
+		{amount: 15, rate: 0.1, want: 2},
+		{amount: 10000, rate: 0, want: 0},
+		{amount: 10000, rate: -0.25, want: -2500},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.MulRate(tt.rate); got != tt.want {
+			t.Errorf("Money(%d).MulRate(%v) = %d, want %d", tt.amount, tt.rate, got, tt.want)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		src     any
+		want    Money
+		wantErr bool
+	}{
+		{src: nil, want: 0},
+		{src: int64(12), want: 1200},
+		{src: 0.125, want: 13},
+		{src: []byte("10.005"), want: 1001},
+		{src: "-3.10", want: -310},
+		{src: "not money", wantErr: true},
+		{src: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var got Money
+		err := got.Scan(tt.src)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Scan(%v) = %v, want an error", tt.src, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Scan(%v) returned error: %v", tt.src, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Scan(%v) = %d cents, want %d", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestValueRoundTrip(t *testing.T) {
+	for _, amount := range []Money{0, 1, -1, 5, 1205, -1205, 123456789} {
+		value, err := amount.Value()
+		if err != nil {
+			t.Fatalf("Money(%d).Value() returned error: %v", amount, err)
+		}
+
+		var scanned Money
+		if err := scanned.Scan(value); err != nil {
+			t.Fatalf("Scan(%v) returned error: %v", value, err)
+		}
+		if scanned != amount {
+			t.Errorf("Money(%d) stored as %v scans back as %d", amount, value, scanned)
+		}
+	}
+
+	value, _ := Money(-1205).Value()
+	if value != "-12.05" {
+		t.Errorf("Money(-1205).Value() = %v, want -12.05", value)
+	}
+}
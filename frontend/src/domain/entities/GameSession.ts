@@ -9,27 +9,130 @@ export interface SessionMetadata {
   holdings: Record<string, HoldingInfo>;
 }
 
+export type GameMode = 'classic' | 'challenge' | 'room';
+
+export type Difficulty = 'easy' | 'normal' | 'hard' | 'expert';
+
+export interface GameRules {
+  weeks: number;
+  starting_cash: number;
+  stocks_per_category: number;
+  headlines_per_week: number;
+  difficulty: Difficulty;
+}
+
 export interface GameSession {
   session_id: string;
   username: string;
   cash: number;
   holdings_value: number;
   total_balance: number;
+  rules: GameRules;
+  mode: GameMode;
+  challenge_date?: string;
+  room_code?: string;
   status: GameSessionStatus;
+  day: number;
   metadata: SessionMetadata;
   created_at: string;
   updated_at: string;
 }
 
+export interface LeaderboardEntry extends GameSession {
+  rank: number;
+}
+
+export interface LeaderboardPage {
+  entries: LeaderboardEntry[];
+  total: number;
+  page: number;
+  page_size: number;
+}
+
 export interface CreateSessionRequest {
   username: string;
   categories: string[];
+  difficulty?: Difficulty;
+}
+
+export interface StartChallengeRequest {
+  username: string;
 }
 
 export interface CreateSessionResponse {
   sessionId: string;
 }
 
+export type RoomStatus = 'crafting' | 'playing' | 'finished' | 'failed';
+
+export interface RoomMember {
+  username: string;
+  host: boolean;
+  ready: boolean;
+}
+
+export interface Room {
+  code: string;
+  status: RoomStatus;
+  week: number;
+  rules: GameRules;
+  categories: string[];
+  members: RoomMember[];
+  created_at: string;
+}
+
+export interface RoomStanding {
+  rank: number;
+  username: string;
+  cash: number;
+  holdings_value: number;
+  total_balance: number;
+  return: number;
+  status: GameSessionStatus;
+  ready: boolean;
+}
+
+export interface JoinRoomRequest {
+  username: string;
+}
+
+export interface CreateRoomResponse extends CreateSessionResponse {
+  code: string;
+}
+
+export type SessionEventType =
+  | 'crafting_progress'
+  | 'crafting_succeeded'
+  | 'crafting_failed'
+  | 'week_advanced'
+  | 'order_filled'
+  | 'session_finished'
+  | 'session_expiring';
+
+export type CraftingStep = 'categories' | 'stocks' | 'scenario' | 'saving';
+
+export interface FilledTrade {
+  ticker: string;
+  side: 'buy' | 'sell';
+  quantity: number;
+  price: number;
+  fee: number;
+  source: string;
+  week: number;
+  day: number;
+}
+
+export interface SessionEvent {
+  type: SessionEventType;
+  step?: CraftingStep;
+  week?: number;
+  day?: number;
+  trades?: FilledTrade[];
+  message?: string;
+  expires_at?: string;
+  created_at: string;
+}
+
 export interface TradeRequest {
   ticker: string;
   quantity: number;
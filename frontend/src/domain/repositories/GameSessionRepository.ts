@@ -1,4 +1,4 @@
-import type { GameSession, CreateSessionRequest, CreateSessionResponse, TradeRequest } from '../entities/GameSession';
+import type { GameSession, CreateSessionRequest, CreateSessionResponse, TradeRequest, LeaderboardPage, StartChallengeRequest, SessionEvent, Room, RoomStanding, JoinRoomRequest, CreateRoomResponse, GameRules } from '../entities/GameSession';
 import type { WeekData } from '../services/GameSessionService';
 
 interface GameResults {
@@ -6,15 +6,26 @@ interface GameResults {
   status: string;
   total_balance: number;
   username: string;
+  rules: GameRules;
 }
 
 export interface GameSessionRepository {
-  getLeaderboard(): Promise<GameSession[]>;
+  getLeaderboard(): Promise<LeaderboardPage>;
+  getChallengeLeaderboard(date?: string): Promise<LeaderboardPage>;
+  startChallenge(request: StartChallengeRequest): Promise<CreateSessionResponse>;
   createSession(request: CreateSessionRequest): Promise<CreateSessionResponse>;
+  createRoom(request: CreateSessionRequest): Promise<CreateRoomResponse>;
+  joinRoom(code: string, request: JoinRoomRequest): Promise<CreateSessionResponse>;
+  getRoom(code: string): Promise<Room>;
+  getRoomLeaderboard(code: string): Promise<RoomStanding[]>;
+  setReady(sessionId: string): Promise<Room>;
+  advanceRoom(sessionId: string): Promise<Room>;
   getSessionState(sessionId: string): Promise<GameSession>;
+  subscribeToEvents(sessionId: string, onEvent: (event: SessionEvent) => void, onOpen?: () => void): () => void;
   buyStocks(sessionId: string, request: TradeRequest): Promise<void>;
   sellStocks(sessionId: string, request: TradeRequest): Promise<void>;
   advanceWeek(sessionId: string): Promise<void>;
+  advanceDay(sessionId: string): Promise<void>;
   endSession(sessionId: string): Promise<GameResults>;
   getWeekData(week: number, sessionId: string): Promise<WeekData>;
 }
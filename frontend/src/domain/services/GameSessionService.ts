@@ -1,4 +1,4 @@
-import type { GameSession, CreateSessionRequest, CreateSessionResponse, TradeRequest } from '../entities/GameSession';
+import type { GameSession, CreateSessionRequest, CreateSessionResponse, TradeRequest, LeaderboardPage, StartChallengeRequest, SessionEvent, Room, RoomStanding, JoinRoomRequest, CreateRoomResponse, GameRules } from '../entities/GameSession';
 import type { GameSessionRepository } from '../repositories/GameSessionRepository';
 
 export interface ApiStock {
@@ -9,11 +9,27 @@ export interface ApiStock {
   rating_to: string;
   action: 'upgraded' | 'downgraded' | 'target raised' | 'target lowered' | 'reiterated';
   price: number;
+  daily_prices?: number[];
+}
+
+export interface Headline {
+  text: string;
+  tickers?: string[];
+  categories?: string[];
+  direction?: 'up' | 'down' | 'mixed';
+}
+
+export interface CorporateAction {
+  ticker: string;
+  type: 'dividend' | 'split' | 'reverse_split';
+  amount?: number;
+  ratio?: number;
 }
 
 export interface WeekData {
-  headlines: string[];
+  headlines: Headline[];
   stocks: ApiStock[];
+  events?: CorporateAction[];
 }
 
 interface GameResults {
@@ -21,21 +37,68 @@ interface GameResults {
   status: string;
   total_balance: number;
   username: string;
+  rules: GameRules;
 }
 
 export class GameSessionService {
   constructor(private readonly repository: GameSessionRepository) {}
 
-  async getLeaderboard(): Promise<GameSession[]> {
+  async getLeaderboard(): Promise<LeaderboardPage> {
     return this.repository.getLeaderboard();
   }
 
+  async getChallengeLeaderboard(date?: string): Promise<LeaderboardPage> {
+    return this.repository.getChallengeLeaderboard(date);
+  }
+
+  async startChallenge(request: StartChallengeRequest): Promise<CreateSessionResponse> {
+    const response = await this.repository.startChallenge(request);
+    localStorage.setItem('sessionId', response.sessionId);
+    return response;
+  }
+
   async createSession(request: CreateSessionRequest): Promise<CreateSessionResponse> {
     const response = await this.repository.createSession(request);
     localStorage.setItem('sessionId', response.sessionId);
     return response;
   }
 
+  async createRoom(request: CreateSessionRequest): Promise<CreateRoomResponse> {
+    const response = await this.repository.createRoom(request);
+    localStorage.setItem('sessionId', response.sessionId);
+    return response;
+  }
+
+  async joinRoom(code: string, request: JoinRoomRequest): Promise<CreateSessionResponse> {
+    const response = await this.repository.joinRoom(code, request);
+    localStorage.setItem('sessionId', response.sessionId);
+    return response;
+  }
+
+  async getRoom(code: string): Promise<Room> {
+    return this.repository.getRoom(code);
+  }
+
+  async getRoomLeaderboard(code: string): Promise<RoomStanding[]> {
+    return this.repository.getRoomLeaderboard(code);
+  }
+
+  async setReady(): Promise<Room> {
+    const sessionId = localStorage.getItem('sessionId');
+    if (!sessionId) {
+      throw new Error('No active session');
+    }
+    return this.repository.setReady(sessionId);
+  }
+
+  async advanceRoom(): Promise<Room> {
+    const sessionId = localStorage.getItem('sessionId');
+    if (!sessionId) {
+      throw new Error('No active session');
+    }
+    return this.repository.advanceRoom(sessionId);
+  }
+
   async getSessionState(): Promise<GameSession> {
     const sessionId = localStorage.getItem('sessionId');
     if (!sessionId) {
@@ -60,6 +123,14 @@ export class GameSessionService {
     await this.repository.sellStocks(sessionId, request);
   }
 
+  subscribeToEvents(onEvent: (event: SessionEvent) => void, onOpen?: () => void): () => void {
+    const sessionId = localStorage.getItem('sessionId');
+    if (!sessionId) {
+      throw new Error('No active session');
+    }
+    return this.repository.subscribeToEvents(sessionId, onEvent, onOpen);
+  }
+
   async advanceWeek(): Promise<void> {
     const sessionId = localStorage.getItem('sessionId');
     if (!sessionId) {
@@ -68,6 +139,14 @@ export class GameSessionService {
     await this.repository.advanceWeek(sessionId);
   }
 
+  async advanceDay(): Promise<void> {
+    const sessionId = localStorage.getItem('sessionId');
+    if (!sessionId) {
+      throw new Error('No active session');
+    }
+    await this.repository.advanceDay(sessionId);
+  }
+
   async endSession(): Promise<GameResults> {
     const sessionId = localStorage.getItem('sessionId');
     if (!sessionId) {
@@ -4,12 +4,23 @@ export const API_BASE_URL = apiUrl.startsWith('http://localhost') ? apiUrl : api
 
 export const endpoints = {
   categories: '/categories',
+  challengeLeaderboard: '/challenge/leaderboard',
+  challengeStart: '/challenge/start',
   gmWeek: (week: number) => `/game/week/${week}`,
   leaderboard: '/leaderboard',
+  rooms: '/room',
+  roomAdvance: '/room/advance',
+  roomReady: '/room/ready',
+  roomByCode: (code: string) => `/room/${encodeURIComponent(code)}`,
+  roomJoin: (code: string) => `/room/${encodeURIComponent(code)}/join`,
+  roomLeaderboard: (code: string) => `/room/${encodeURIComponent(code)}/leaderboard`,
   sessions: '/session/start',
   sessionsAdvance: '/session/advance',
+  sessionsAdvanceDay: '/session/advance-day',
   sessionsBuy: '/session/buy',
   sessionsEnd: '/session/end',
+  sessionsEvents: '/session/events',
+  sessionsEventsTicket: '/session/events/ticket',
   sessionsSell: '/session/sell',
   sessionsState: '/session/state',
   stocks: '/stocks',
@@ -3,30 +3,98 @@ import type {
   CreateSessionRequest,
   CreateSessionResponse,
   TradeRequest,
+  LeaderboardPage,
+  StartChallengeRequest,
+  Room,
+  RoomStanding,
+  JoinRoomRequest,
+  CreateRoomResponse,
+  SessionEvent,
+  SessionEventType,
+  GameRules,
 } from '../../domain/entities/GameSession';
 import type { GameSessionRepository } from '../../domain/repositories/GameSessionRepository';
 import type { WeekData } from '../../domain/services/GameSessionService';
 import { HttpClient } from '../http/HttpClient';
-import { endpoints } from '../api/config';
+import { API_BASE_URL, endpoints } from '../api/config';
 
 interface GameResults {
   cash: number;
   status: string;
   total_balance: number;
   username: string;
+  rules: GameRules;
 }
 
+interface StreamTicket {
+  ticket: string;
+  expiresIn: number;
+}
+
+const streamReconnectDelayMs = 3000;
+
+const sessionEventTypes: SessionEventType[] = [
+  'crafting_progress',
+  'crafting_succeeded',
+  'crafting_failed',
+  'week_advanced',
+  'order_filled',
+  'session_finished',
+  'session_expiring',
+];
+
 export class GameSessionApiRepository implements GameSessionRepository {
   constructor(private readonly httpClient: HttpClient) {}
 
-  async getLeaderboard(): Promise<GameSession[]> {
-    return this.httpClient.get<GameSession[]>(endpoints.leaderboard);
+  async getLeaderboard(): Promise<LeaderboardPage> {
+    return this.httpClient.get<LeaderboardPage>(endpoints.leaderboard);
+  }
+
+  async getChallengeLeaderboard(date?: string): Promise<LeaderboardPage> {
+    const query = date ? `?date=${encodeURIComponent(date)}` : '';
+    return this.httpClient.get<LeaderboardPage>(`${endpoints.challengeLeaderboard}${query}`);
+  }
+
+  async startChallenge(request: StartChallengeRequest): Promise<CreateSessionResponse> {
+    return this.httpClient.post<CreateSessionResponse>(endpoints.challengeStart, request);
   }
 
   async createSession(request: CreateSessionRequest): Promise<CreateSessionResponse> {
     return this.httpClient.post<CreateSessionResponse>(endpoints.sessions, request);
   }
 
+  async createRoom(request: CreateSessionRequest): Promise<CreateRoomResponse> {
+    return this.httpClient.post<CreateRoomResponse>(endpoints.rooms, request);
+  }
+
+  async joinRoom(code: string, request: JoinRoomRequest): Promise<CreateSessionResponse> {
+    return this.httpClient.post<CreateSessionResponse>(endpoints.roomJoin(code), request);
+  }
+
+  async getRoom(code: string): Promise<Room> {
+    return this.httpClient.get<Room>(endpoints.roomByCode(code));
+  }
+
+  async getRoomLeaderboard(code: string): Promise<RoomStanding[]> {
+    return this.httpClient.get<RoomStanding[]>(endpoints.roomLeaderboard(code));
+  }
+
+  async setReady(sessionId: string): Promise<Room> {
+    return this.httpClient.post<Room>(endpoints.roomReady, undefined, {
+      headers: {
+        Authorization: `Bearer ${sessionId}`,
+      },
+    });
+  }
+
+  async advanceRoom(sessionId: string): Promise<Room> {
+    return this.httpClient.post<Room>(endpoints.roomAdvance, undefined, {
+      headers: {
+        Authorization: `Bearer ${sessionId}`,
+      },
+    });
+  }
+
   async getSessionState(sessionId: string): Promise<GameSession> {
     return this.httpClient.get<GameSession>(endpoints.sessionsState, {
       headers: {
@@ -35,6 +103,50 @@ export class GameSessionApiRepository implements GameSessionRepository {
     });
   }
 
+  // EventSource cannot send headers, so the session token goes in the query.
+  // EventSource cannot send the session token as a header, so each stream is
+  // opened with a single-use ticket. A spent ticket cannot reconnect, so the
+  // stream is reopened with a fresh one whenever the connection drops.
+  subscribeToEvents(sessionId: string, onEvent: (event: SessionEvent) => void, onOpen?: () => void): () => void {
+    let source: EventSource | null = null;
+    let closed = false;
+    const listener = (message: MessageEvent) => onEvent(JSON.parse(message.data) as SessionEvent);
+
+    const open = async () => {
+      let ticket: string;
+      try {
+        ({ ticket } = await this.httpClient.post<StreamTicket>(endpoints.sessionsEventsTicket, undefined, {
+          headers: {
+            Authorization: `Bearer ${sessionId}`,
+          },
+        }));
+      } catch (error) {
+        console.error('Failed to get an event stream ticket:', error);
+        return;
+      }
+      if (closed) return;
+
+      const stream = new EventSource(`${API_BASE_URL}${endpoints.sessionsEvents}?ticket=${encodeURIComponent(ticket)}`);
+      if (onOpen) {
+        stream.onopen = () => onOpen();
+      }
+      stream.onerror = () => {
+        stream.close();
+        if (!closed) {
+          setTimeout(open, streamReconnectDelayMs);
+        }
+      };
+      sessionEventTypes.forEach(type => stream.addEventListener(type, listener));
+      source = stream;
+    };
+    open();
+
+    return () => {
+      closed = true;
+      source?.close();
+    };
+  }
+
   async buyStocks(sessionId: string, request: TradeRequest): Promise<void> {
     await this.httpClient.post(endpoints.sessionsBuy, request, {
       headers: {
@@ -59,6 +171,14 @@ export class GameSessionApiRepository implements GameSessionRepository {
     });
   }
 
+  async advanceDay(sessionId: string): Promise<void> {
+    await this.httpClient.post(endpoints.sessionsAdvanceDay, undefined, {
+      headers: {
+        Authorization: `Bearer ${sessionId}`,
+      },
+    });
+  }
+
   async endSession(sessionId: string): Promise<GameResults> {
     return this.httpClient.post<GameResults>(endpoints.sessionsEnd, undefined, {
       headers: {
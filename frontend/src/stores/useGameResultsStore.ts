@@ -1,11 +1,13 @@
 import { defineStore } from 'pinia';
 import { ref } from 'vue';
+import type { GameRules } from '../domain/entities/GameSession';
 
 interface GameResults {
   cash: number;
   status: string;
   total_balance: number;
   username: string;
+  rules: GameRules;
 }
 
 export const useGameResultsStore = defineStore('gameResults', () => {